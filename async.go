@@ -0,0 +1,243 @@
+package clout
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultAsyncQueueSize is the default number of messages that an AsyncPrinter can queue.
+const defaultAsyncQueueSize = 1024
+
+// OverflowPolicy decides what an AsyncPrinter does when its queue is full.
+type OverflowPolicy int
+
+const (
+	// BlockWhenFull waits until there is room in the queue.
+	// No messages are lost, but a slow output will slow down the goroutines printing messages.
+	BlockWhenFull OverflowPolicy = iota
+
+	// DropOldestWhenFull removes the oldest queued message to make room for the new one.
+	DropOldestWhenFull OverflowPolicy = iota
+
+	// DropNewestWhenFull discards the new message.
+	DropNewestWhenFull OverflowPolicy = iota
+)
+
+// AsyncPrinter is a PrinterInterface that queues messages and prints them to another printer in the background.
+// This prevents slow outputs (e.g. a terminal or a blocked pipe) from stalling the goroutines that print messages.
+//
+// If messages are dropped because the queue is full, the number of dropped messages is printed as a Warning once
+// there is room again. Use Flush to wait for the queued messages to be printed, and Close to stop the background
+// goroutine. Exit and SetPrinter flush the printer automatically.
+//
+// Example:
+//
+//     printer := clout.NewAsyncPrinter(clout.GetPrinter(), 1024).SetOverflowPolicy(clout.DropOldestWhenFull)
+//     clout.SetPrinter(printer)
+//     defer printer.Close()
+type AsyncPrinter struct {
+	printer PrinterInterface
+	size    int
+	policy  OverflowPolicy
+
+	mutex    sync.Mutex
+	cond     *sync.Cond
+	queue    []Message // A ring buffer of queued messages, starting at head.
+	head     int
+	count    int
+	printing bool
+	closed   bool
+	stopped  bool
+	dropped  int
+	total    int
+	done     chan struct{}
+}
+
+// NewAsyncPrinter creates an AsyncPrinter that prints to another PrinterInterface.
+// The size is the maximum number of queued messages; if it is less than 1, a default size is used.
+//
+// This starts a goroutine that runs until the AsyncPrinter is closed.
+func NewAsyncPrinter(printer PrinterInterface, size int) *AsyncPrinter {
+	if size < 1 {
+		size = defaultAsyncQueueSize
+	}
+
+	p := &AsyncPrinter{
+		printer: printer,
+		size:    size,
+		queue:   make([]Message, size),
+		done:    make(chan struct{}),
+	}
+
+	p.cond = sync.NewCond(&p.mutex)
+	go p.run()
+	return p
+}
+
+// SetOverflowPolicy changes what the AsyncPrinter does when its queue is full.
+// The default policy is BlockWhenFull.
+func (p *AsyncPrinter) SetOverflowPolicy(policy OverflowPolicy) *AsyncPrinter {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.policy = policy
+	return p
+}
+
+// Print queues a Message to be printed.
+// If the AsyncPrinter is closed, the Message is printed immediately instead.
+func (p *AsyncPrinter) Print(message Message) {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		p.printer.Print(message)
+		return
+	}
+
+	if p.count >= p.size {
+		switch p.policy {
+		case DropOldestWhenFull:
+			p.pop()
+			p.dropped++
+			p.total++
+
+		case DropNewestWhenFull:
+			p.dropped++
+			p.total++
+			p.mutex.Unlock()
+			return
+
+		default:
+			for p.count >= p.size && !p.closed {
+				p.cond.Wait()
+			}
+
+			if p.closed {
+				p.mutex.Unlock()
+				p.printer.Print(message)
+				return
+			}
+		}
+	}
+
+	p.queue[(p.head+p.count)%p.size] = message
+	p.count++
+	p.cond.Broadcast()
+	p.mutex.Unlock()
+}
+
+// Capture returns the information that the wrapped PrinterInterface needs captured.
+// This is captured when the message is created, not when it is printed.
+func (p *AsyncPrinter) Capture() Capture {
+	return captureOf(p.printer)
+}
+
+// MaxVerbosity returns the highest MessageVerbosity that the wrapped PrinterInterface wants to receive.
+func (p *AsyncPrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.printer)
+}
+
+// Flush waits until all queued messages are printed, then flushes the wrapped PrinterInterface.
+// If the context is done first, this returns the context's error.
+//
+// This must not be called from inside the wrapped PrinterInterface, since it would wait for itself.
+func (p *AsyncPrinter) Flush(ctx context.Context) error {
+	drained := make(chan struct{})
+	cancelled := false
+	go func() {
+		p.mutex.Lock()
+		for (p.count > 0 || p.dropped > 0 || p.printing) && !p.stopped && !cancelled {
+			p.cond.Wait()
+		}
+		p.mutex.Unlock()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		// Wake up the waiting goroutine so that it doesn't wait forever if the output is stuck.
+		p.mutex.Lock()
+		cancelled = true
+		p.cond.Broadcast()
+		p.mutex.Unlock()
+
+		<-drained
+		return ctx.Err()
+	}
+
+	return FlushPrinter(ctx, p.printer)
+}
+
+// Close prints all queued messages, stops the background goroutine, and flushes the wrapped PrinterInterface.
+// Messages printed after the AsyncPrinter is closed are printed immediately.
+func (p *AsyncPrinter) Close() error {
+	p.mutex.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mutex.Unlock()
+
+	<-p.done
+	return FlushPrinter(context.Background(), p.printer)
+}
+
+// Dropped returns the total number of messages that were dropped because the queue was full.
+func (p *AsyncPrinter) Dropped() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.total
+}
+
+// run prints queued messages until the AsyncPrinter is closed and its queue is empty.
+func (p *AsyncPrinter) run() {
+	defer close(p.done)
+
+	p.mutex.Lock()
+	for {
+		for p.count == 0 && p.dropped == 0 && !p.closed {
+			p.cond.Wait()
+		}
+
+		if p.count == 0 && p.dropped == 0 {
+			p.stopped = true
+			p.cond.Broadcast()
+			p.mutex.Unlock()
+			return
+		}
+
+		// Take the next message, or a notice about dropped messages.
+		var message Message
+		if p.dropped > 0 {
+			message = New(Warning, 0, "(%s dropped because the output was too slow)",
+				pluralize(p.dropped, "message was", "messages were"))
+			p.dropped = 0
+		} else {
+			message = p.pop()
+		}
+
+		p.printing = true
+		p.cond.Broadcast()
+		p.mutex.Unlock()
+
+		p.printer.Print(message)
+
+		// Exiting would wait for this goroutine to flush the queue, so a broken pipe exits from another goroutine.
+		if atomic.LoadInt32(&brokenPipe) != 0 {
+			go exitOnBrokenPipe()
+		}
+
+		p.mutex.Lock()
+		p.printing = false
+		p.cond.Broadcast()
+	}
+}
+
+// pop removes the oldest Message from the queue.
+// This must be called while holding the mutex.
+func (p *AsyncPrinter) pop() Message {
+	message := p.queue[p.head]
+	p.queue[p.head] = Message{}
+	p.head = (p.head + 1) % p.size
+	p.count--
+	return message
+}
@@ -0,0 +1,234 @@
+package clout
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testBlockingPrinter is a PrinterInterface that waits for the gate channel before printing each message.
+type testBlockingPrinter struct {
+	gate     chan struct{}
+	started  chan struct{}
+	mutex    sync.Mutex
+	messages []string
+}
+
+func newTestBlockingPrinter() *testBlockingPrinter {
+	return &testBlockingPrinter{
+		gate:    make(chan struct{}),
+		started: make(chan struct{}, 100),
+	}
+}
+
+func (p *testBlockingPrinter) Print(message Message) {
+	p.started <- struct{}{}
+	<-p.gate
+
+	p.mutex.Lock()
+	p.messages = append(p.messages, message.String())
+	p.mutex.Unlock()
+}
+
+func (p *testBlockingPrinter) printed() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]string(nil), p.messages...)
+}
+
+func TestAsyncPrinter(t *testing.T) {
+	inner := &testFlushPrinter{}
+	p := NewAsyncPrinter(inner, 0)
+	defer p.Close()
+
+	for _, text := range []string{"a", "b", "c"} {
+		p.Print(New(Info, 2, text))
+	}
+
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, message := range inner.messages {
+		got = append(got, message.String())
+	}
+
+	diff := cmp.Diff([]string{"a", "b", "c"}, got)
+	if diff != "" {
+		t.Log("did not find expected messages; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+
+	if inner.flushed != 1 {
+		t.Fatalf("expected wrapped printer to be flushed once, got %d", inner.flushed)
+	}
+}
+
+func TestAsyncPrinterOverflow(t *testing.T) {
+	tests := map[string]struct {
+		policy          OverflowPolicy
+		messages        int
+		expected        []string
+		expectedDropped int
+	}{
+		"Drop Oldest": {
+			policy:          DropOldestWhenFull,
+			messages:        4,
+			expected:        []string{"0", "(2 messages were dropped because the output was too slow)", "3", "4"},
+			expectedDropped: 2,
+		},
+		"Drop Oldest Many": {
+			policy:          DropOldestWhenFull,
+			messages:        1000,
+			expected:        []string{"0", "(998 messages were dropped because the output was too slow)", "999", "1000"},
+			expectedDropped: 998,
+		},
+		"Drop Newest": {
+			policy:          DropNewestWhenFull,
+			messages:        4,
+			expected:        []string{"0", "(2 messages were dropped because the output was too slow)", "1", "2"},
+			expectedDropped: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			inner := newTestBlockingPrinter()
+			p := NewAsyncPrinter(inner, 2).SetOverflowPolicy(tc.policy)
+
+			// Wait for the first message to be taken from the queue, then fill the queue.
+			p.Print(New(Info, 2, "0"))
+			<-inner.started
+			for i := 1; i <= tc.messages; i++ {
+				p.Print(New(Info, 2, "%d", i))
+			}
+
+			close(inner.gate)
+			if err := p.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(tc.expected, inner.printed())
+			if diff != "" {
+				t.Log("did not find expected messages; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+
+			if dropped := p.Dropped(); dropped != tc.expectedDropped {
+				t.Fatalf("expected %d dropped messages, got %d", tc.expectedDropped, dropped)
+			}
+		})
+	}
+}
+
+func TestAsyncPrinterBlock(t *testing.T) {
+	inner := newTestBlockingPrinter()
+	p := NewAsyncPrinter(inner, 1)
+
+	p.Print(New(Info, 2, "0"))
+	<-inner.started
+	p.Print(New(Info, 2, "1"))
+
+	printed := make(chan struct{})
+	go func() {
+		p.Print(New(Info, 2, "2"))
+		close(printed)
+	}()
+
+	select {
+	case <-printed:
+		t.Fatalf("expected Print to block while the queue is full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(inner.gate)
+	<-printed
+	_ = p.Close()
+
+	diff := cmp.Diff([]string{"0", "1", "2"}, inner.printed())
+	if diff != "" {
+		t.Log("did not find expected messages; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
+
+func TestAsyncPrinterFlushTimeout(t *testing.T) {
+	inner := newTestBlockingPrinter()
+	p := NewAsyncPrinter(inner, 1)
+	defer func() {
+		close(inner.gate)
+		_ = p.Close()
+	}()
+
+	p.Print(New(Info, 2, "0"))
+	<-inner.started
+	goroutines := runtime.NumGoroutine()
+
+	for i := 0; i < 10; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		err := p.Flush(ctx)
+		cancel()
+
+		if err != context.DeadlineExceeded {
+			t.Fatalf("expected flush to time out, got %v", err)
+		}
+	}
+
+	// The goroutines waiting for the timed out flushes should stop, even though the output is still stuck.
+	// They may take a moment to exit after they're done waiting.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if leaked := runtime.NumGoroutine() - goroutines; leaked > 0 {
+		t.Fatalf("expected no goroutines to be left waiting, got %d", leaked)
+	}
+}
+
+func TestAsyncPrinterClosed(t *testing.T) {
+	inner := &testFlushPrinter{}
+	p := NewAsyncPrinter(inner, 1)
+	_ = p.Close()
+
+	p.Print(New(Info, 2, "after close"))
+	if len(inner.messages) != 1 {
+		t.Fatalf("expected message to be printed immediately after close, got %d messages", len(inner.messages))
+	}
+}
+
+func TestAsyncPrinterSetPrinter(t *testing.T) {
+	resetGlobals(t)
+
+	inner := &testFlushPrinter{}
+	p := NewAsyncPrinter(inner, 0)
+	defer p.Close()
+
+	SetPrinter(p)
+	V(0).Infof("hello")
+	SetPrinter(&testPrinter{})
+
+	if len(inner.messages) != 1 {
+		t.Fatalf("expected SetPrinter to flush the previous printer, got %d messages", len(inner.messages))
+	}
+}
+
+func TestAsyncPrinterFatal(t *testing.T) {
+	resetGlobals(t)
+	code := captureExit(t)
+
+	inner := &testFlushPrinter{}
+	p := NewAsyncPrinter(inner, 0)
+	defer p.Close()
+
+	V(0).WithPrinter(p).Fatalf("oops")
+
+	if *code != 1 || len(inner.messages) != 1 {
+		t.Fatalf("expected message to be flushed before exiting, got code %d and %d messages", *code, len(inner.messages))
+	}
+}
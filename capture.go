@@ -0,0 +1,87 @@
+package clout
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Capture is a set of flags for optional information that is captured when a Message is created.
+// Capturing information has a small performance cost, so it is only done when the printer needs it.
+type Capture int
+
+const (
+	// CaptureTime captures the time that the Message was created.
+	CaptureTime Capture = 1 << iota
+
+	// CaptureCaller captures the source file, line, and function that created the Message.
+	CaptureCaller Capture = 1 << iota
+)
+
+// CapturingPrinter is an optional interface for PrinterInterface implementations that need captured information.
+type CapturingPrinter interface {
+	PrinterInterface
+
+	// Capture returns the information that should be captured for each Message.
+	Capture() Capture
+}
+
+// Caller is the location in the source code where a Message was created.
+type Caller struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// String returns the base name of the file and the line number (e.g. "main.go:42").
+// If the Caller is unknown, this returns an empty string.
+func (c Caller) String() string {
+	if c.File == "" {
+		return ""
+	}
+
+	return filepath.Base(c.File) + ":" + strconv.Itoa(c.Line)
+}
+
+// programStart is the time that the program started.
+// This is used for relative timestamps.
+var programStart = time.Now()
+
+// verboseMethodPrefix is the function name prefix of the Verbose methods.
+// This is used to find the first caller outside of the clout package.
+var verboseMethodPrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return functionPackage(runtime.FuncForPC(pc).Name()) + ".(*Verbose)."
+}()
+
+// captureOf returns the information that a PrinterInterface needs captured.
+func captureOf(printer PrinterInterface) Capture {
+	if capturing, ok := printer.(CapturingPrinter); ok {
+		return capturing.Capture()
+	}
+
+	return 0
+}
+
+// captureCaller finds the first caller outside of the Verbose methods.
+// The skip parameter is the number of additional stack frames to skip after that.
+func captureCaller(skip int) Caller {
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, verboseMethodPrefix) {
+			if skip <= 0 {
+				return Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+			}
+
+			skip--
+		}
+
+		if !more {
+			return Caller{}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package clout
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+type testCapturingPrinter struct {
+	testPrinter
+	capture Capture
+}
+
+func (p *testCapturingPrinter) Capture() Capture {
+	return p.capture
+}
+
+func TestCaptureCaller(t *testing.T) {
+	tests := map[string]struct {
+		fn func(v *Verbose)
+	}{
+		"Infof":  {fn: func(v *Verbose) { v.Infof("hello") }},
+		"Infoln": {fn: func(v *Verbose) { v.Infoln("hello") }},
+		"Info":   {fn: func(v *Verbose) { v.Info("hello") }},
+		"Err":    {fn: func(v *Verbose) { v.Err(errors.New("hello")) }},
+		"WithCallDepth": {fn: func(v *Verbose) {
+			wrapper := func() { v.WithCallDepth(1).Infof("hello") }
+			wrapper()
+		}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &testCapturingPrinter{capture: CaptureCaller}
+			v := (&Verbose{enabled: true}).WithPrinter(p)
+
+			tc.fn(v)
+
+			if len(p.messages) != 1 {
+				t.Fatalf("expected 1 message, got %d", len(p.messages))
+			}
+
+			caller := p.messages[0].Caller()
+			if filepath.Base(caller.File) != "capture_test.go" {
+				t.Fatalf("expected caller in capture_test.go, got %q (%s)", caller.File, caller.Function)
+			}
+		})
+	}
+}
+
+func TestCaptureDisabled(t *testing.T) {
+	p := &testPrinter{}
+	v := (&Verbose{enabled: true}).WithPrinter(p)
+	v.Infof("hello")
+
+	if !p.messages[0].Time().IsZero() || p.messages[0].Caller() != (Caller{}) {
+		t.Fatalf("expected nothing to be captured, got %v", p.messages[0])
+	}
+}
+
+func TestCaptureTime(t *testing.T) {
+	p := &testCapturingPrinter{capture: CaptureTime}
+	v := (&Verbose{enabled: true}).WithPrinter(p)
+	v.Infof("hello")
+
+	if p.messages[0].Time().IsZero() {
+		t.Fatalf("expected time to be captured")
+	}
+
+	if p.messages[0].Caller() != (Caller{}) {
+		t.Fatalf("expected caller not to be captured, got %v", p.messages[0].Caller())
+	}
+}
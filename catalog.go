@@ -0,0 +1,205 @@
+package clout
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownCode is returned when looking up a code that was not registered in a Catalog.
+var ErrUnknownCode = errors.New("unknown code")
+
+// CatalogEntry is a coded message registered in a Catalog.
+type CatalogEntry struct {
+	// Code is the stable identifier of the message (e.g. "E0142").
+	Code string
+
+	// Summary is a short, one-line description of the message.
+	Summary string
+
+	// Explanation is a long-form explanation of the message, its causes, and how to fix it.
+	Explanation string
+}
+
+// Catalog is a collection of coded messages.
+//
+// Codes give warnings and errors a stable identifier that can be referenced by documentation and searched for, even
+// if the wording of the message changes. Messages are printed with a code through Verbose.Code, and the catalog keeps
+// track of which codes were printed so that tests can check for duplicate, unused, or unregistered codes.
+//
+// Example:
+//
+//     clout.RegisterCode("E0142", "unknown config key", "The config file contains a key that is not recognized...")
+//     clout.V(1).Code("E0142").Errorf("unknown key %q", key) // -> error[E0142]: unknown key "nmae"
+type Catalog struct {
+	mutex      sync.Mutex
+	entries    map[string]CatalogEntry
+	duplicates []string
+	used       map[string]bool
+}
+
+// NewCatalog creates a new, empty Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		entries: make(map[string]CatalogEntry),
+		used:    make(map[string]bool),
+	}
+}
+
+// Register adds a coded message to the Catalog.
+// If the code is already registered, the original entry is kept and the code is reported by Duplicates.
+func (c *Catalog) Register(code string, summary string, explanation string) *Catalog {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.entries[code]; exists {
+		c.duplicates = append(c.duplicates, code)
+		return c
+	}
+
+	c.entries[code] = CatalogEntry{
+		Code:        code,
+		Summary:     summary,
+		Explanation: explanation,
+	}
+
+	return c
+}
+
+// Lookup finds the CatalogEntry for a code.
+func (c *Catalog) Lookup(code string) (CatalogEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[code]
+	return entry, ok
+}
+
+// Codes returns a sorted list of the registered codes.
+func (c *Catalog) Codes() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	codes := make([]string, 0, len(c.entries))
+	for code := range c.entries {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+	return codes
+}
+
+// Explain writes the summary and explanation of a code to an io.Writer.
+// If the code is not registered, this returns an error wrapping ErrUnknownCode.
+func (c *Catalog) Explain(writer io.Writer, code string) error {
+	entry, ok := c.Lookup(code)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownCode, code)
+	}
+
+	_, err := fmt.Fprintf(writer, "%s: %s\n\n%s\n", entry.Code, entry.Summary, strings.TrimSpace(entry.Explanation))
+	return err
+}
+
+// Duplicates returns the codes that were registered more than once.
+func (c *Catalog) Duplicates() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]string(nil), c.duplicates...)
+}
+
+// Unused returns a sorted list of the registered codes that have not been printed.
+// This is only meaningful after the code paths that print the messages have been run (e.g. at the end of a test suite).
+func (c *Catalog) Unused() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var codes []string
+	for code := range c.entries {
+		if !c.used[code] {
+			codes = append(codes, code)
+		}
+	}
+
+	sort.Strings(codes)
+	return codes
+}
+
+// Unknown returns a sorted list of the codes that were printed without being registered.
+func (c *Catalog) Unknown() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var codes []string
+	for code := range c.used {
+		if _, ok := c.entries[code]; !ok {
+			codes = append(codes, code)
+		}
+	}
+
+	sort.Strings(codes)
+	return codes
+}
+
+// Check returns an error if any codes were registered more than once, or printed without being registered.
+// Unused codes are not included, since they depend on which code paths were run; use Unused to check for those.
+func (c *Catalog) Check() error {
+	var problems []string
+	if duplicates := c.Duplicates(); len(duplicates) > 0 {
+		problems = append(problems, "duplicate codes: "+strings.Join(duplicates, ", "))
+	}
+
+	if unknown := c.Unknown(); len(unknown) > 0 {
+		problems = append(problems, "unregistered codes: "+strings.Join(unknown, ", "))
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// markUsed records that a code was printed.
+func (c *Catalog) markUsed(code string) {
+	c.mutex.Lock()
+	c.used[code] = true
+	c.mutex.Unlock()
+}
+
+var globalCatalogMutex sync.RWMutex
+var globalCatalog = NewCatalog()
+
+// GetCatalog gets the global Catalog used by Verbose.Code.
+func GetCatalog() *Catalog {
+	globalCatalogMutex.RLock()
+	defer globalCatalogMutex.RUnlock()
+	return globalCatalog
+}
+
+// SetCatalog sets the global Catalog used by Verbose.Code.
+func SetCatalog(catalog *Catalog) {
+	globalCatalogMutex.Lock()
+	globalCatalog = catalog
+	globalCatalogMutex.Unlock()
+}
+
+// RegisterCode adds a coded message to the global Catalog.
+func RegisterCode(code string, summary string, explanation string) {
+	GetCatalog().Register(code, summary, explanation)
+}
+
+// Explain writes the summary and explanation of a code from the global Catalog to stdout.
+// This is intended for implementing an "explain" command (e.g. "mytool explain E0142").
+//
+// The explanation is the output that the user asked for, so it is written directly instead of being printed as a
+// Message. This means it is not affected by the verbosity or quiet settings. Use Catalog.Explain to write it to a
+// different io.Writer.
+//
+// If the code is not registered, nothing is printed and an error wrapping ErrUnknownCode is returned.
+func Explain(code string) error {
+	return GetCatalog().Explain(os.Stdout, code)
+}
@@ -0,0 +1,132 @@
+package clout
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := NewCatalog().
+		Register("E0001", "first", "The first explanation.").
+		Register("E0002", "second", "The second explanation.").
+		Register("E0001", "duplicate", "A duplicate explanation.")
+
+	catalog.markUsed("E0002")
+	catalog.markUsed("E9999")
+
+	tests := map[string]struct {
+		expected []string
+		actual   []string
+	}{
+		"Codes":      {expected: []string{"E0001", "E0002"}, actual: catalog.Codes()},
+		"Duplicates": {expected: []string{"E0001"}, actual: catalog.Duplicates()},
+		"Unused":     {expected: []string{"E0001"}, actual: catalog.Unused()},
+		"Unknown":    {expected: []string{"E9999"}, actual: catalog.Unknown()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff := cmp.Diff(tc.expected, tc.actual)
+			if diff != "" {
+				t.Log("did not find expected codes; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+
+	if entry, _ := catalog.Lookup("E0001"); entry.Summary != "first" {
+		t.Fatalf("expected duplicate registration to keep the original entry, got %q", entry.Summary)
+	}
+
+	expectedErr := "duplicate codes: E0001; unregistered codes: E9999"
+	if err := catalog.Check(); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected error %q, got %v", expectedErr, err)
+	}
+}
+
+func TestCatalogCheckValid(t *testing.T) {
+	catalog := NewCatalog().Register("E0001", "first", "The first explanation.")
+	if err := catalog.Check(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestCatalogExplain(t *testing.T) {
+	catalog := NewCatalog().Register("E0142", "unknown config key", "\nThe config file contains an unknown key.\n")
+
+	buf := new(bytes.Buffer)
+	if err := catalog.Explain(buf, "E0142"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "E0142: unknown config key\n\nThe config file contains an unknown key.\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+
+	if err := catalog.Explain(buf, "E0000"); !errors.Is(err, ErrUnknownCode) {
+		t.Fatalf("expected ErrUnknownCode, got %v", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	resetGlobals(t)
+	oldCatalog := GetCatalog()
+	defer SetCatalog(oldCatalog)
+	SetCatalog(NewCatalog().Register("E0142", "unknown config key", "The config file contains an unknown key."))
+
+	// The explanation should be written even if the printer would discard Info messages.
+	p := &testPrinter{}
+	SetPrinter(quietPrinter{p})
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	err = Explain("E0142")
+	os.Stdout = stdout
+	_ = writer.Close()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, _ := io.ReadAll(reader)
+	expected := "E0142: unknown config key\n\nThe config file contains an unknown key.\n"
+	if string(output) != expected || len(p.messages) != 0 {
+		t.Fatalf("expected %q on stdout and no messages, got %q and %d messages", expected, output, len(p.messages))
+	}
+}
+
+func TestVerboseCode(t *testing.T) {
+	oldCatalog := GetCatalog()
+	defer SetCatalog(oldCatalog)
+
+	catalog := NewCatalog().Register("E0142", "unknown config key", "")
+	SetCatalog(catalog)
+
+	p := &testPrinter{}
+	v := &Verbose{enabled: true, printer: p}
+	v.Code("E0142").Errorf("unknown key")
+	v.Errorf("no code")
+
+	if code := p.messages[0].Code(); code != "E0142" {
+		t.Fatalf("expected code E0142, got %q", code)
+	}
+
+	if code := p.messages[1].Code(); code != "" {
+		t.Fatalf("expected no code, got %q", code)
+	}
+
+	if unused := catalog.Unused(); len(unused) != 0 {
+		t.Fatalf("expected printed code to be marked as used, got unused %v", unused)
+	}
+}
@@ -2,15 +2,26 @@ package clout
 
 import (
 	"io"
+	"time"
 )
 
 // Verbose is used to build messages.
 //
 // It's named after the klog equivalent for compatibility reasons.
 type Verbose struct {
-	verbosity MessageVerbosity
-	printer   PrinterInterface
-	enabled   bool
+	verbosity  MessageVerbosity
+	printer    PrinterInterface
+	enabled    bool
+	fields     []Field
+	name       string
+	diagnostic *Diagnostic
+	code       string
+	kind       MessageKind
+	hasKind    bool
+	capture    Capture
+	callDepth  int
+
+	aboveVerbosity bool
 }
 
 // Enabled returns true if the message will be printed.
@@ -19,10 +30,115 @@ func (v *Verbose) Enabled() bool {
 	return v.enabled
 }
 
+// WithValues creates a copy of the Verbose that attaches key/value fields to every printed Message.
+// The keysAndValues are alternating keys and values, and are appended after any existing fields.
+//
+// Example:
+//
+//     v := clout.V(2).WithValues("file", path)
+//     v.Warningf("unknown key %q", key) // -> warning: unknown key "foo" file=config.yaml
+func (v *Verbose) WithValues(keysAndValues ...interface{}) *Verbose {
+	clone := *v
+	clone.fields = appendFields(v.fields, keysAndValues)
+	return &clone
+}
+
+// WithName creates a copy of the Verbose that attaches a name to every printed Message.
+// If the Verbose already has a name, the new name is appended to it with a "/" separator.
+func (v *Verbose) WithName(name string) *Verbose {
+	clone := *v
+	if v.name != "" && name != "" {
+		clone.name = v.name + "/" + name
+	} else if name != "" {
+		clone.name = name
+	}
+
+	return &clone
+}
+
+// WithDiagnostic creates a copy of the Verbose that attaches a source location to every printed Message.
+//
+// Example:
+//
+//     d := clout.NewDiagnostic("config.yaml", 12, 5).WithSpan(4).WithSource(line).WithHelp("did you mean %q?", "name")
+//     clout.V(1).WithDiagnostic(d).Errorf("unknown key %q", "nmae")
+func (v *Verbose) WithDiagnostic(diagnostic Diagnostic) *Verbose {
+	clone := *v
+	clone.diagnostic = &diagnostic
+	return &clone
+}
+
+// Code creates a copy of the Verbose that attaches a stable message code to every printed Message.
+// The code should be registered in the global Catalog (see RegisterCode), and is printed as part of the prefix.
+//
+// Example:
+//
+//     clout.V(1).Code("E0142").Errorf("unknown key %q", key) // -> error[E0142]: unknown key "nmae"
+func (v *Verbose) Code(code string) *Verbose {
+	clone := *v
+	clone.code = code
+	return &clone
+}
+
+// Once creates a copy of the Verbose that only prints a message the first time it is used with a key.
+// This is intended for one-shot warnings that would otherwise be printed every time a code path is run.
+//
+// The keys are shared by the whole program instead of being tracked by a DedupPrinter, so a one-shot warning stays
+// one-shot even if no DedupPrinter is installed or the printer is replaced with SetPrinter. Use ResetOnce to forget
+// the keys.
+//
+// Example:
+//
+//     clout.V(1).Once("legacy-config").Warningf("the legacy config format will be removed in v2")
+func (v *Verbose) Once(key string) *Verbose {
+	clone := *v
+	clone.printer = oncePrinter{printer: v.printer, key: key}
+	return &clone
+}
+
+// Kind creates a copy of the Verbose that prints a MessageKind with Printf, Println, and Print.
+// This is intended for kinds registered with RegisterKind.
+//
+// Example:
+//
+//     clout.V(2).Kind(Skipped).Printf("%d tests were skipped", n)
+func (v *Verbose) Kind(kind MessageKind) *Verbose {
+	clone := *v
+	clone.kind = kind
+	clone.hasKind = true
+	return &clone
+}
+
+// WithPrinter creates a copy of the Verbose that prints to a different PrinterInterface.
+//
+// If the Verbose is above the verbosity setting, whether it is enabled depends on the new printer's MaxVerbosity
+// instead of the old printer's (see VerbosityPrinter).
+func (v *Verbose) WithPrinter(printer PrinterInterface) *Verbose {
+	clone := *v
+	clone.printer = printer
+	clone.capture = captureOf(printer)
+
+	if !v.enabled || v.aboveVerbosity {
+		max, ok := maxVerbosityOf(printer)
+		clone.enabled = ok && v.verbosity <= max
+		clone.aboveVerbosity = clone.enabled
+	}
+
+	return &clone
+}
+
+// WithCallDepth creates a copy of the Verbose that skips additional stack frames when capturing the caller.
+// This is intended for wrappers around clout, so the captured caller is the caller of the wrapper.
+func (v *Verbose) WithCallDepth(depth int) *Verbose {
+	clone := *v
+	clone.callDepth += depth
+	return &clone
+}
+
 // Deprecationf prints a formatted Deprecation warning message.
 func (v *Verbose) Deprecationf(format string, args ...interface{}) {
 	if v.Enabled() {
-		v.printer.Print(New(Deprecation, v.verbosity, format, args...))
+		v.print(Deprecation, format, args)
 	}
 }
 
@@ -41,7 +157,7 @@ func (v *Verbose) Deprecation(args ...interface{}) {
 // Warningf prints a formatted Warning message.
 func (v *Verbose) Warningf(format string, args ...interface{}) {
 	if v.Enabled() {
-		v.printer.Print(New(Warning, v.verbosity, format, args...))
+		v.print(Warning, format, args)
 	}
 }
 
@@ -60,7 +176,7 @@ func (v *Verbose) Warning(args ...interface{}) {
 // Errorf prints a formatted Error message.
 func (v *Verbose) Errorf(format string, args ...interface{}) {
 	if v.Enabled() {
-		v.printer.Print(New(Error, v.verbosity, format, args...))
+		v.print(Error, format, args)
 	}
 }
 
@@ -76,10 +192,59 @@ func (v *Verbose) Error(args ...interface{}) {
 	v.Errorln(args...)
 }
 
+// Err prints an Error message for an error.
+//
+// The default Printer will print the chain of wrapped errors (see errors.Unwrap) and errors.Join branches as an
+// indented tree, along with any hints or details provided by errors implementing ErrorHint or ErrorDetails.
+//
+// Example:
+//
+//     clout.V(1).Err(fmt.Errorf("read config: %w", err))
+//     // -> error: read config
+//     //      caused by: open config.yaml: no such file or directory
+func (v *Verbose) Err(err error) {
+	if v.Enabled() && err != nil {
+		message := v.message(Error, "%s", []interface{}{newErrorNode(err).collapse().summary()})
+		message.err = err
+		v.printer.Print(message)
+		exitOnBrokenPipe()
+	}
+}
+
+// Fatalf prints a formatted Error message, then exits the program through Exit.
+// The exit code can be changed with SetFatalExitCode.
+//
+// Unlike other messages, this is printed even if the Verbose is not enabled.
+func (v *Verbose) Fatalf(format string, args ...interface{}) {
+	v.printer.Print(v.message(Error, format, args))
+	exitPrinter(v.printer, getFatalExitCode())
+}
+
+// Fatalln prints an Error message, then exits the program through Exit.
+// The exit code can be changed with SetFatalExitCode.
+//
+// Unlike other messages, this is printed even if the Verbose is not enabled.
+func (v *Verbose) Fatalln(args ...interface{}) {
+	v.Fatalf(argsToFormat(args), args...)
+}
+
+// Fatal is an alias for Fatalln.
+func (v *Verbose) Fatal(args ...interface{}) {
+	v.Fatalln(args...)
+}
+
+// Exitf prints a formatted Error message, then exits the program through Exit with the provided exit code.
+//
+// Unlike other messages, this is printed even if the Verbose is not enabled.
+func (v *Verbose) Exitf(code int, format string, args ...interface{}) {
+	v.printer.Print(v.message(Error, format, args))
+	exitPrinter(v.printer, code)
+}
+
 // Statusf prints a formatted Status message.
 func (v *Verbose) Statusf(format string, args ...interface{}) {
 	if v.Enabled() {
-		v.printer.Print(New(Status, v.verbosity, format, args...))
+		v.print(Status, format, args)
 	}
 }
 
@@ -98,7 +263,7 @@ func (v *Verbose) Status(args ...interface{}) {
 // Infof prints a formatted Info message.
 func (v *Verbose) Infof(format string, args ...interface{}) {
 	if v.Enabled() {
-		v.printer.Print(New(Info, v.verbosity, format, args...))
+		v.print(Info, format, args)
 	}
 }
 
@@ -114,6 +279,114 @@ func (v *Verbose) Info(args ...interface{}) {
 	v.Infoln(args...)
 }
 
+// Debugf prints a formatted Debug message.
+func (v *Verbose) Debugf(format string, args ...interface{}) {
+	if v.Enabled() {
+		v.print(Debug, format, args)
+	}
+}
+
+// Debugln prints a Debug message.
+func (v *Verbose) Debugln(args ...interface{}) {
+	if v.Enabled() {
+		v.Debugf(argsToFormat(args), args...)
+	}
+}
+
+// Debug is an alias for Debugln.
+func (v *Verbose) Debug(args ...interface{}) {
+	v.Debugln(args...)
+}
+
+// Tracef prints a formatted Trace message.
+func (v *Verbose) Tracef(format string, args ...interface{}) {
+	if v.Enabled() {
+		v.print(Trace, format, args)
+	}
+}
+
+// Traceln prints a Trace message.
+func (v *Verbose) Traceln(args ...interface{}) {
+	if v.Enabled() {
+		v.Tracef(argsToFormat(args), args...)
+	}
+}
+
+// Trace is an alias for Traceln.
+func (v *Verbose) Trace(args ...interface{}) {
+	v.Traceln(args...)
+}
+
+// Successf prints a formatted Success message.
+func (v *Verbose) Successf(format string, args ...interface{}) {
+	if v.Enabled() {
+		v.print(Success, format, args)
+	}
+}
+
+// Successln prints a Success message.
+func (v *Verbose) Successln(args ...interface{}) {
+	if v.Enabled() {
+		v.Successf(argsToFormat(args), args...)
+	}
+}
+
+// Success is an alias for Successln.
+func (v *Verbose) Success(args ...interface{}) {
+	v.Successln(args...)
+}
+
+// Hintf prints a formatted Hint message.
+func (v *Verbose) Hintf(format string, args ...interface{}) {
+	if v.Enabled() {
+		v.print(Hint, format, args)
+	}
+}
+
+// Hintln prints a Hint message.
+func (v *Verbose) Hintln(args ...interface{}) {
+	if v.Enabled() {
+		v.Hintf(argsToFormat(args), args...)
+	}
+}
+
+// Hint is an alias for Hintln.
+func (v *Verbose) Hint(args ...interface{}) {
+	v.Hintln(args...)
+}
+
+// Printf prints a formatted message of the MessageKind selected with Kind.
+// If no MessageKind was selected, an Info message is printed.
+func (v *Verbose) Printf(format string, args ...interface{}) {
+	if v.Enabled() {
+		v.print(v.printKind(), format, args)
+	}
+}
+
+// Println prints a message of the MessageKind selected with Kind.
+// If no MessageKind was selected, an Info message is printed.
+func (v *Verbose) Println(args ...interface{}) {
+	if v.Enabled() {
+		v.Printf(argsToFormat(args), args...)
+	}
+}
+
+// Print is an alias for Println.
+func (v *Verbose) Print(args ...interface{}) {
+	v.Println(args...)
+}
+
+// PrintMessage prints a Message that was created outside of the Verbose (e.g. by an adapter for another logging
+// library). The Message is given the verbosity of the Verbose, but keeps its own kind, fields, time, and caller.
+func (v *Verbose) PrintMessage(message Message) {
+	if v.Enabled() {
+		message.verbosity = v.verbosity
+		message.aboveVerbosity = v.aboveVerbosity
+		v.printer.Print(message)
+		exitOnBrokenPipe()
+	}
+}
+
 // AsWriter creates an io.Writer that prints all incoming lines of text through the clout package.
 // This is intended to convert the stdout and stderr of an executed command into Message objects.
 //
@@ -130,8 +403,50 @@ func (v *Verbose) AsWriter(kind MessageKind) io.Writer {
 	return &messageWriter{
 		Printer: v.printer,
 		Converter: func(text string) *Message {
-			msg := New(kind, v.verbosity, "%s", text)
+			msg := v.message(kind, "%s", []interface{}{text})
+			msg.caller = Caller{} // The caller would be the io.Writer, which isn't useful.
 			return &msg
 		},
 	}
 }
+
+// message creates a new Message with the name, fields, diagnostic, and code of the Verbose.
+// If the printer requires it, the time and caller will also be captured.
+func (v *Verbose) message(kind MessageKind, format string, args []interface{}) Message {
+	message := New(kind, v.verbosity, format, args...)
+	message.fields = v.fields
+	message.name = v.name
+	message.diagnostic = v.diagnostic
+	message.code = v.code
+	message.aboveVerbosity = v.aboveVerbosity
+
+	if v.code != "" {
+		GetCatalog().markUsed(v.code)
+	}
+
+	if v.capture&CaptureTime != 0 {
+		message.time = time.Now()
+	}
+
+	if v.capture&CaptureCaller != 0 {
+		message.caller = captureCaller(v.callDepth)
+	}
+
+	return message
+}
+
+// printKind returns the MessageKind selected with Kind, or Info if no kind was selected.
+func (v *Verbose) printKind() MessageKind {
+	if !v.hasKind {
+		return Info
+	}
+
+	return v.kind
+}
+
+// print creates and prints a new Message.
+// If the output was a closed pipe, the program exits afterwards (see ExitOnBrokenPipe).
+func (v *Verbose) print(kind MessageKind, format string, args []interface{}) {
+	v.printer.Print(v.message(kind, format, args))
+	exitOnBrokenPipe()
+}
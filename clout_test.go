@@ -171,6 +171,147 @@ func TestVerbose(t *testing.T) {
 				v.Error("error")
 			},
 		},
+		"Debugf": {
+			expected: []Message{{
+				format:     "hello %s",
+				formatArgs: []interface{}{"debugf"},
+				kind:       Debug,
+			}},
+			fn: func(v Verbose) {
+				v.Debugf("hello %s", "debugf")
+			},
+		},
+		"Debugln": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"debugln"},
+				kind:       Debug,
+			}},
+			fn: func(v Verbose) {
+				v.Debugln("debugln")
+			},
+		},
+		"Debug": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"debug"},
+				kind:       Debug,
+			}},
+			fn: func(v Verbose) {
+				v.Debug("debug")
+			},
+		},
+		"Tracef": {
+			expected: []Message{{
+				format:     "hello %s",
+				formatArgs: []interface{}{"tracef"},
+				kind:       Trace,
+			}},
+			fn: func(v Verbose) {
+				v.Tracef("hello %s", "tracef")
+			},
+		},
+		"Traceln": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"traceln"},
+				kind:       Trace,
+			}},
+			fn: func(v Verbose) {
+				v.Traceln("traceln")
+			},
+		},
+		"Trace": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"trace"},
+				kind:       Trace,
+			}},
+			fn: func(v Verbose) {
+				v.Trace("trace")
+			},
+		},
+		"Successf": {
+			expected: []Message{{
+				format:     "hello %s",
+				formatArgs: []interface{}{"successf"},
+				kind:       Success,
+			}},
+			fn: func(v Verbose) {
+				v.Successf("hello %s", "successf")
+			},
+		},
+		"Successln": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"successln"},
+				kind:       Success,
+			}},
+			fn: func(v Verbose) {
+				v.Successln("successln")
+			},
+		},
+		"Success": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"success"},
+				kind:       Success,
+			}},
+			fn: func(v Verbose) {
+				v.Success("success")
+			},
+		},
+		"Hintf": {
+			expected: []Message{{
+				format:     "hello %s",
+				formatArgs: []interface{}{"hintf"},
+				kind:       Hint,
+			}},
+			fn: func(v Verbose) {
+				v.Hintf("hello %s", "hintf")
+			},
+		},
+		"Hintln": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"hintln"},
+				kind:       Hint,
+			}},
+			fn: func(v Verbose) {
+				v.Hintln("hintln")
+			},
+		},
+		"Hint": {
+			expected: []Message{{
+				format:     "%v",
+				formatArgs: []interface{}{"hint"},
+				kind:       Hint,
+			}},
+			fn: func(v Verbose) {
+				v.Hint("hint")
+			},
+		},
+		"WithValues": {
+			expected: []Message{{
+				format:     "hello %s",
+				formatArgs: []interface{}{"fields"},
+				kind:       Info,
+				fields:     []Field{{Key: "a", Value: 1}, {Key: "b", Value: "two"}},
+			}},
+			fn: func(v Verbose) {
+				v.WithValues("a", 1).WithValues("b", "two").Infof("hello %s", "fields")
+			},
+		},
+		"WithName": {
+			expected: []Message{{
+				format: "hello",
+				kind:   Info,
+				name:   "outer/inner",
+			}},
+			fn: func(v Verbose) {
+				v.WithName("outer").WithName("inner").Infof("hello")
+			},
+		},
 		"misc: verbosity": {
 			verbosity: 2,
 			expected: []Message{{
@@ -0,0 +1,266 @@
+package clout
+
+import (
+	"context"
+	"reflect"
+)
+
+// Tee creates a PrinterInterface that prints every Message to all of the printers.
+//
+// Example:
+//
+//     clout.SetPrinter(clout.Tee(clout.GetPrinter(), clout.NewJSONPrinter(logFile)))
+func Tee(printers ...PrinterInterface) PrinterInterface {
+	return &teePrinter{printers: printers}
+}
+
+// Filter creates a PrinterInterface that only prints the messages that the predicate returns true for.
+//
+// Example:
+//
+//     errorsOnly := clout.Filter(func(m clout.Message) bool {
+//         return m.Kind().Severity() >= clout.ErrorSeverity
+//     }, clout.GetPrinter())
+func Filter(predicate func(message Message) bool, printer PrinterInterface) PrinterInterface {
+	return &filterPrinter{predicate: predicate, printer: printer}
+}
+
+// Map creates a PrinterInterface that changes every Message before printing it.
+//
+// Example:
+//
+//     withHost := clout.Map(func(m clout.Message) clout.Message {
+//         return m.WithFields(clout.Field{Key: "host", Value: hostname})
+//     }, clout.GetPrinter())
+func Map(fn func(message Message) Message, printer PrinterInterface) PrinterInterface {
+	return &mapPrinter{fn: fn, printer: printer}
+}
+
+// RouteByKind creates a PrinterInterface that prints each Message to the printer for its MessageKind.
+// Messages with a kind that has no route are printed to the fallback printer, or discarded if the fallback is nil.
+//
+// Example:
+//
+//     clout.SetPrinter(clout.RouteByKind(map[clout.MessageKind]clout.PrinterInterface{
+//         clout.Debug: debugPrinter,
+//         clout.Trace: debugPrinter,
+//     }, clout.GetPrinter()))
+func RouteByKind(routes map[MessageKind]PrinterInterface, fallback PrinterInterface) PrinterInterface {
+	copied := make(map[MessageKind]PrinterInterface, len(routes))
+	for kind, printer := range routes {
+		copied[kind] = printer
+	}
+
+	return &kindRouter{routes: copied, fallback: fallback}
+}
+
+// RouteByVerbosity creates a PrinterInterface that prints messages at or below the maximum verbosity to one printer,
+// and more verbose messages to the fallback printer. If either printer is nil, its messages are discarded.
+//
+// Example:
+//
+//     // Print V(0) to V(2) on the console, and everything up to V(5) to a log file.
+//     clout.SetVerbosity(5)
+//     clout.SetPrinter(clout.Tee(
+//         clout.RouteByVerbosity(2, clout.GetPrinter(), nil),
+//         clout.NewJSONPrinter(logFile),
+//     ))
+func RouteByVerbosity(maxVerbosity MessageVerbosity, printer PrinterInterface, fallback PrinterInterface) PrinterInterface {
+	return &verbosityRouter{maxVerbosity: maxVerbosity, printer: printer, fallback: fallback}
+}
+
+// teePrinter is the PrinterInterface created by Tee.
+type teePrinter struct {
+	printers []PrinterInterface
+}
+
+func (p *teePrinter) Print(message Message) {
+	for _, printer := range p.printers {
+		if wantsMessage(printer, &message) {
+			printer.Print(message)
+		}
+	}
+}
+
+func (p *teePrinter) Capture() Capture {
+	return captureOfAll(p.printers...)
+}
+
+func (p *teePrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOfAll(p.printers...)
+}
+
+func (p *teePrinter) Flush(ctx context.Context) error {
+	return flushAll(ctx, p.printers...)
+}
+
+// filterPrinter is the PrinterInterface created by Filter.
+type filterPrinter struct {
+	predicate func(message Message) bool
+	printer   PrinterInterface
+}
+
+func (p *filterPrinter) Print(message Message) {
+	if p.predicate(message) {
+		p.printer.Print(message)
+	}
+}
+
+func (p *filterPrinter) Capture() Capture {
+	return captureOf(p.printer)
+}
+
+func (p *filterPrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.printer)
+}
+
+func (p *filterPrinter) Flush(ctx context.Context) error {
+	return FlushPrinter(ctx, p.printer)
+}
+
+// mapPrinter is the PrinterInterface created by Map.
+type mapPrinter struct {
+	fn      func(message Message) Message
+	printer PrinterInterface
+}
+
+func (p *mapPrinter) Print(message Message) {
+	p.printer.Print(p.fn(message))
+}
+
+func (p *mapPrinter) Capture() Capture {
+	return captureOf(p.printer)
+}
+
+func (p *mapPrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.printer)
+}
+
+func (p *mapPrinter) Flush(ctx context.Context) error {
+	return FlushPrinter(ctx, p.printer)
+}
+
+// kindRouter is the PrinterInterface created by RouteByKind.
+type kindRouter struct {
+	routes   map[MessageKind]PrinterInterface
+	fallback PrinterInterface
+}
+
+func (p *kindRouter) Print(message Message) {
+	printer, ok := p.routes[message.Kind()]
+	if !ok {
+		printer = p.fallback
+	}
+
+	if wantsMessage(printer, &message) {
+		printer.Print(message)
+	}
+}
+
+func (p *kindRouter) Capture() Capture {
+	return captureOfAll(p.printers()...)
+}
+
+func (p *kindRouter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOfAll(p.printers()...)
+}
+
+func (p *kindRouter) Flush(ctx context.Context) error {
+	return flushAll(ctx, p.printers()...)
+}
+
+// printers returns the printers that the kindRouter routes to.
+func (p *kindRouter) printers() []PrinterInterface {
+	printers := []PrinterInterface{p.fallback}
+	for _, printer := range p.routes {
+		printers = append(printers, printer)
+	}
+
+	return printers
+}
+
+// verbosityRouter is the PrinterInterface created by RouteByVerbosity.
+type verbosityRouter struct {
+	maxVerbosity MessageVerbosity
+	printer      PrinterInterface
+	fallback     PrinterInterface
+}
+
+func (p *verbosityRouter) Print(message Message) {
+	printer := p.printer
+	if message.Verbosity() > p.maxVerbosity {
+		printer = p.fallback
+	}
+
+	if wantsMessage(printer, &message) {
+		printer.Print(message)
+	}
+}
+
+func (p *verbosityRouter) Capture() Capture {
+	return captureOfAll(p.printer, p.fallback)
+}
+
+func (p *verbosityRouter) MaxVerbosity() (MessageVerbosity, bool) {
+	// The first printer never receives messages above the router's max verbosity.
+	max, ok := maxVerbosityOf(p.printer)
+	if ok && max > p.maxVerbosity {
+		max = p.maxVerbosity
+	}
+
+	if fallbackMax, fallbackOk := maxVerbosityOf(p.fallback); fallbackOk && (!ok || fallbackMax > max) {
+		max, ok = fallbackMax, true
+	}
+
+	return max, ok
+}
+
+func (p *verbosityRouter) Flush(ctx context.Context) error {
+	return flushAll(ctx, p.printer, p.fallback)
+}
+
+// captureOfAll returns the information that any of the printers need captured.
+// Nil printers are ignored.
+func captureOfAll(printers ...PrinterInterface) Capture {
+	var capture Capture
+	for _, printer := range printers {
+		if printer != nil {
+			capture |= captureOf(printer)
+		}
+	}
+
+	return capture
+}
+
+// flushAll flushes each distinct printer once, and returns the first error.
+// Nil printers are ignored.
+//
+// Only pointer printers are checked for duplicates, since comparing other types with == can panic. Those may be
+// flushed more than once, which is harmless.
+func flushAll(ctx context.Context, printers ...PrinterInterface) error {
+	var firstErr error
+	flushed := make([]PrinterInterface, 0, len(printers))
+
+outer:
+	for _, printer := range printers {
+		if printer == nil {
+			continue
+		}
+
+		if reflect.TypeOf(printer).Kind() == reflect.Ptr {
+			for _, other := range flushed {
+				if printer == other {
+					continue outer
+				}
+			}
+
+			flushed = append(flushed, printer)
+		}
+
+		if err := FlushPrinter(ctx, printer); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,221 @@
+package clout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCombinators(t *testing.T) {
+	tests := map[string]struct {
+		expected map[string][]string
+		printer  func(printers map[string]PrinterInterface) PrinterInterface
+	}{
+		"Tee": {
+			expected: map[string][]string{
+				"a": {"warning", "info V(3)", "debug V(5)"},
+				"b": {"warning", "info V(3)", "debug V(5)"},
+			},
+			printer: func(printers map[string]PrinterInterface) PrinterInterface {
+				return Tee(printers["a"], printers["b"])
+			},
+		},
+		"Filter": {
+			expected: map[string][]string{
+				"a": {"warning"},
+			},
+			printer: func(printers map[string]PrinterInterface) PrinterInterface {
+				return Filter(func(m Message) bool {
+					return m.Kind().Severity() >= WarningSeverity
+				}, printers["a"])
+			},
+		},
+		"Map": {
+			expected: map[string][]string{
+				"a": {"warning tag=1", "info V(3) tag=1", "debug V(5) tag=1"},
+			},
+			printer: func(printers map[string]PrinterInterface) PrinterInterface {
+				return Map(func(m Message) Message {
+					return m.WithFields(Field{Key: "tag", Value: 1})
+				}, printers["a"])
+			},
+		},
+		"RouteByKind": {
+			expected: map[string][]string{
+				"a": {"debug V(5)"},
+				"b": {"warning", "info V(3)"},
+			},
+			printer: func(printers map[string]PrinterInterface) PrinterInterface {
+				return RouteByKind(map[MessageKind]PrinterInterface{
+					Debug: printers["a"],
+				}, printers["b"])
+			},
+		},
+		"RouteByKind Without Fallback": {
+			expected: map[string][]string{
+				"a": {"warning"},
+			},
+			printer: func(printers map[string]PrinterInterface) PrinterInterface {
+				return RouteByKind(map[MessageKind]PrinterInterface{
+					Warning: printers["a"],
+				}, nil)
+			},
+		},
+		"RouteByVerbosity": {
+			expected: map[string][]string{
+				"a": {"warning", "info V(3)"},
+				"b": {"debug V(5)"},
+			},
+			printer: func(printers map[string]PrinterInterface) PrinterInterface {
+				return RouteByVerbosity(3, printers["a"], printers["b"])
+			},
+		},
+		"Console And Log File": {
+			expected: map[string][]string{
+				"console": {"warning"},
+				"log":     {"warning", "info V(3)", "debug V(5)"},
+			},
+			printer: func(printers map[string]PrinterInterface) PrinterInterface {
+				return Tee(RouteByVerbosity(2, printers["console"], nil), printers["log"])
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printers := make(map[string]PrinterInterface)
+			results := make(map[string]*testPrinter)
+			for key := range tc.expected {
+				results[key] = &testPrinter{}
+				printers[key] = results[key]
+			}
+
+			p := tc.printer(printers)
+			p.Print(New(Warning, 0, "warning"))
+			p.Print(New(Info, 3, "info V(3)"))
+			p.Print(New(Debug, 5, "debug V(5)"))
+
+			got := make(map[string][]string)
+			for key, result := range results {
+				for _, message := range result.messages {
+					got[key] = append(got[key], message.String()+formatFields(&message, false))
+				}
+			}
+
+			diff := cmp.Diff(tc.expected, got)
+			if diff != "" {
+				t.Log("did not find expected messages; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestCombinatorsForwardCaptureAndFlush(t *testing.T) {
+	a := &testFlushPrinter{}
+	b := &testCapturingPrinter{capture: CaptureCaller}
+
+	printers := map[string]PrinterInterface{
+		"Tee":              Tee(a, b),
+		"Filter":           Tee(Filter(func(Message) bool { return true }, a), Filter(func(Message) bool { return true }, b)),
+		"Map":              Tee(Map(func(m Message) Message { return m }, a), Map(func(m Message) Message { return m }, b)),
+		"RouteByKind":      RouteByKind(map[MessageKind]PrinterInterface{Error: a, Warning: a}, b),
+		"RouteByVerbosity": RouteByVerbosity(0, a, b),
+	}
+
+	for name, p := range printers {
+		t.Run(name, func(t *testing.T) {
+			a.flushed = 0
+			if err := FlushPrinter(context.Background(), p); err != nil {
+				t.Fatal(err)
+			}
+
+			if a.flushed != 1 {
+				t.Errorf("expected printer to be flushed once, got %d", a.flushed)
+			}
+
+			if captureOf(p) != CaptureCaller {
+				t.Errorf("expected capture to be forwarded, got %v", captureOf(p))
+			}
+		})
+	}
+}
+
+func TestCombinatorsValuePrinters(t *testing.T) {
+	resetGlobals(t)
+	code := captureExit(t)
+
+	var messages []Message
+	a := testValuePrinter{messages: &messages}
+	b := testValuePrinter{messages: &messages}
+
+	printers := map[string]PrinterInterface{
+		"Tee":              Tee(a, b),
+		"RouteByKind":      RouteByKind(map[MessageKind]PrinterInterface{Error: a, Warning: b}, a),
+		"RouteByVerbosity": RouteByVerbosity(0, a, b),
+	}
+
+	for name, p := range printers {
+		t.Run(name, func(t *testing.T) {
+			if err := FlushPrinter(context.Background(), p); err != nil {
+				t.Fatal(err)
+			}
+
+			SetPrinter(p)
+			Exit(2)
+			if *code != 2 {
+				t.Fatalf("expected Exit to exit with code 2, got %d", *code)
+			}
+		})
+	}
+}
+
+func TestCombinatorsExit(t *testing.T) {
+	tests := map[string]struct {
+		printer func(p PrinterInterface) PrinterInterface
+	}{
+		"Tee": {
+			printer: func(p PrinterInterface) PrinterInterface { return Tee(p, &testPrinter{}) },
+		},
+		"Filter": {
+			printer: func(p PrinterInterface) PrinterInterface {
+				return Filter(func(Message) bool { return true }, p)
+			},
+		},
+		"Map": {
+			printer: func(p PrinterInterface) PrinterInterface {
+				return Map(func(m Message) Message { return m }, p)
+			},
+		},
+		"RouteByKind": {
+			printer: func(p PrinterInterface) PrinterInterface {
+				return RouteByKind(map[MessageKind]PrinterInterface{Error: p}, p)
+			},
+		},
+		"RouteByVerbosity": {
+			printer: func(p PrinterInterface) PrinterInterface { return RouteByVerbosity(0, p, nil) },
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetGlobals(t)
+			code := captureExit(t)
+
+			p := &testFlushPrinter{}
+			SetPrinter(tc.printer(p))
+
+			V(0).Fatalf("boom")
+			if *code != 1 || len(p.messages) != 1 || p.flushed == 0 {
+				t.Fatalf("expected Fatalf to print, flush, and exit; got code %d, %d messages, %d flushes",
+					*code, len(p.messages), p.flushed)
+			}
+
+			Exit(2)
+			if *code != 2 {
+				t.Fatalf("expected Exit to exit with code 2, got %d", *code)
+			}
+		})
+	}
+}
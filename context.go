@@ -0,0 +1,127 @@
+package clout
+
+import (
+	"context"
+)
+
+// Logger holds the printer, verbosity, name, and fields used to create Verbose messages.
+// Anything that is not set on the Logger falls back to the global settings, so the zero Logger is the same as using
+// the V function directly.
+//
+// Loggers can be attached to a context.Context with IntoContext, allowing per-request or per-job output to be
+// customized without passing a Logger through every function.
+//
+// Example:
+//
+//     ctx = clout.IntoContext(ctx, clout.FromContext(ctx).WithName("job-42").WithValues("attempt", 2))
+//     clout.FromContext(ctx).V(3).Infof("starting") // -> job-42: starting attempt=2
+type Logger struct {
+	printer      PrinterInterface
+	verbosity    MessageVerbosity
+	hasVerbosity bool
+	name         string
+	fields       []Field
+}
+
+// loggerContextKey is the context.Context key for a Logger.
+type loggerContextKey struct{}
+
+// IntoContext creates a copy of a context.Context that carries a Logger.
+func IntoContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext gets the Logger carried by a context.Context.
+// If the context does not carry a Logger, the zero Logger is returned.
+func FromContext(ctx context.Context) Logger {
+	logger, _ := ctx.Value(loggerContextKey{}).(Logger)
+	return logger
+}
+
+// WithPrinter creates a copy of the Logger that prints to a PrinterInterface instead of the global printer.
+// If the printer is nil, the global printer will be used.
+func (l Logger) WithPrinter(printer PrinterInterface) Logger {
+	l.printer = printer
+	return l
+}
+
+// WithVerbosity creates a copy of the Logger that uses its own verbosity instead of GetVerbosity.
+// SetVModule overrides still apply.
+func (l Logger) WithVerbosity(verbosity MessageVerbosity) Logger {
+	l.verbosity = verbosity
+	l.hasVerbosity = true
+	return l
+}
+
+// WithName creates a copy of the Logger that attaches a name to every printed Message.
+// If the Logger already has a name, the new name is appended to it with a "/" separator.
+func (l Logger) WithName(name string) Logger {
+	if l.name != "" && name != "" {
+		l.name = l.name + "/" + name
+	} else if name != "" {
+		l.name = name
+	}
+
+	return l
+}
+
+// WithValues creates a copy of the Logger that attaches key/value fields to every printed Message.
+func (l Logger) WithValues(keysAndValues ...interface{}) Logger {
+	l.fields = appendFields(l.fields, keysAndValues)
+	return l
+}
+
+// Printer returns the PrinterInterface that the Logger prints to.
+func (l Logger) Printer() PrinterInterface {
+	if l.printer == nil {
+		return GetPrinter()
+	}
+
+	return l.printer
+}
+
+// Verbosity returns the minimum MessageVerbosity required for messages from the Logger to be displayed.
+func (l Logger) Verbosity() MessageVerbosity {
+	if !l.hasVerbosity {
+		return GetVerbosity()
+	}
+
+	return l.verbosity
+}
+
+// V creates a struct to print messages with the Logger's printer, verbosity, name, and fields.
+//
+// Example:
+//
+//     clout.FromContext(ctx).V(2).Warningf("unknown path: %v", highlight.Cyan("/not-a-path"))
+func (l Logger) V(verbosity MessageVerbosity) *Verbose {
+	return l.VDepth(1, verbosity)
+}
+
+// VDepth creates a struct to print messages, using the caller depth for vmodule overrides.
+// A depth of 0 is the caller of VDepth, and a depth of 1 is the caller's caller.
+//
+// If the verbosity is above the Logger's verbosity, the messages are still enabled when the printer implements
+// VerbosityPrinter and wants them. Those messages are only printed by the outputs that asked for them.
+func (l Logger) VDepth(depth int, verbosity MessageVerbosity) *Verbose {
+	printer := l.Printer()
+	enabled := verbosity <= l.Verbosity() || vmoduleEnabled(depth+1, verbosity)
+
+	aboveVerbosity := false
+	if !enabled {
+		if max, ok := maxVerbosityOf(printer); ok && verbosity <= max {
+			enabled = true
+			aboveVerbosity = true
+		}
+	}
+
+	return &Verbose{
+		enabled:        enabled,
+		aboveVerbosity: aboveVerbosity,
+		verbosity:      verbosity,
+		printer:        printer,
+		capture:        captureOf(printer),
+		name:           l.name,
+		fields:         l.fields,
+	}
+}
@@ -0,0 +1,107 @@
+package clout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromContext(t *testing.T) {
+	resetGlobals(t)
+
+	global := &testPrinter{}
+	SetPrinter(global)
+
+	tests := map[string]struct {
+		expected  []Message
+		ctx       func(p PrinterInterface) context.Context
+		verbosity MessageVerbosity
+		global    bool
+	}{
+		"Empty Context": {
+			expected:  []Message{{format: "hello", verbosity: 2, kind: Info}},
+			ctx:       func(p PrinterInterface) context.Context { return context.Background() },
+			verbosity: 2,
+			global:    true,
+		},
+		"Empty Context Disabled": {
+			expected:  nil,
+			ctx:       func(p PrinterInterface) context.Context { return context.Background() },
+			verbosity: 3,
+			global:    true,
+		},
+		"Printer": {
+			expected: []Message{{format: "hello", verbosity: 2, kind: Info}},
+			ctx: func(p PrinterInterface) context.Context {
+				return IntoContext(context.Background(), Logger{}.WithPrinter(p))
+			},
+			verbosity: 2,
+		},
+		"Verbosity": {
+			expected: []Message{{format: "hello", verbosity: 5, kind: Info}},
+			ctx: func(p PrinterInterface) context.Context {
+				return IntoContext(context.Background(), Logger{}.WithPrinter(p).WithVerbosity(5))
+			},
+			verbosity: 5,
+		},
+		"Verbosity Disabled": {
+			expected: nil,
+			ctx: func(p PrinterInterface) context.Context {
+				return IntoContext(context.Background(), Logger{}.WithPrinter(p).WithVerbosity(0))
+			},
+			verbosity: 1,
+		},
+		"Name And Fields": {
+			expected: []Message{{
+				format:    "hello",
+				verbosity: 2,
+				kind:      Info,
+				name:      "outer/inner",
+				fields:    []Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+			}},
+			ctx: func(p PrinterInterface) context.Context {
+				ctx := IntoContext(context.Background(), Logger{}.WithPrinter(p).WithName("outer").WithValues("a", 1))
+				return IntoContext(ctx, FromContext(ctx).WithName("inner").WithValues("b", 2))
+			},
+			verbosity: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			global.messages = nil
+			p := &testPrinter{}
+
+			FromContext(tc.ctx(p)).V(tc.verbosity).Infof("hello")
+
+			got := p.messages
+			if tc.global {
+				got = global.messages
+			}
+
+			diff := cmp.Diff(tc.expected, got, cmp.AllowUnexported(Message{}))
+			if diff != "" {
+				t.Log("did not find expected Message; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLoggerFallback(t *testing.T) {
+	resetGlobals(t)
+
+	var logger Logger
+	p := &testPrinter{}
+	SetPrinter(p)
+	SetVerbosity(4)
+
+	if logger.Printer() != PrinterInterface(p) {
+		t.Fatalf("expected the zero Logger to use the global printer")
+	}
+
+	if logger.Verbosity() != 4 {
+		t.Fatalf("expected the zero Logger to use the global verbosity, got %d", logger.Verbosity())
+	}
+}
@@ -0,0 +1,159 @@
+package clout
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// werrorNote is the note attached to warnings that were promoted to errors by a CountingPrinter.
+const werrorNote = "warnings are being treated as errors"
+
+// CountingPrinter is a PrinterInterface that counts the messages of each MessageKind before passing them to another
+// printer. This can be used to print a summary and pick an exit code at the end of a run.
+//
+// When warnings are treated as errors (see SetWarningsAsErrors), Warning and Deprecation messages are printed and
+// counted as Error messages, with a note explaining why.
+//
+// Example:
+//
+//     counter := clout.NewCountingPrinter(clout.GetPrinter())
+//     clout.SetPrinter(counter)
+//     // ...
+//     clout.V(0).Statusf("%s", counter.Summary()) // -> completed with 3 warnings, 1 error
+//     clout.Exit(counter.ExitCode())
+type CountingPrinter struct {
+	printer          PrinterInterface
+	mutex            sync.Mutex
+	counts           map[MessageKind]int
+	warningsAsErrors bool
+}
+
+// NewCountingPrinter creates a CountingPrinter that prints to another PrinterInterface.
+func NewCountingPrinter(printer PrinterInterface) *CountingPrinter {
+	return &CountingPrinter{
+		printer: printer,
+		counts:  make(map[MessageKind]int),
+	}
+}
+
+// SetWarningsAsErrors enables or disables promoting Warning and Deprecation messages to Error messages.
+func (p *CountingPrinter) SetWarningsAsErrors(enabled bool) *CountingPrinter {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.warningsAsErrors = enabled
+	return p
+}
+
+func (p *CountingPrinter) Print(message Message) {
+	// Messages that were only enabled for a more verbose output are not shown to the user, so they aren't counted.
+	if message.aboveVerbosity {
+		p.printer.Print(message)
+		return
+	}
+
+	p.mutex.Lock()
+	if p.warningsAsErrors && (message.kind == Warning || message.kind == Deprecation) {
+		message = promoteWarning(message)
+	}
+
+	p.counts[message.kind]++
+	p.mutex.Unlock()
+
+	p.printer.Print(message)
+}
+
+// Capture returns the information that the wrapped PrinterInterface needs captured.
+func (p *CountingPrinter) Capture() Capture {
+	return captureOf(p.printer)
+}
+
+// MaxVerbosity returns the highest MessageVerbosity that the wrapped PrinterInterface wants to receive.
+func (p *CountingPrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.printer)
+}
+
+// Flush flushes the wrapped PrinterInterface.
+func (p *CountingPrinter) Flush(ctx context.Context) error {
+	return FlushPrinter(ctx, p.printer)
+}
+
+// Count returns the number of messages of a MessageKind that were printed.
+func (p *CountingPrinter) Count(kind MessageKind) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.counts[kind]
+}
+
+// Counts returns the number of messages of each MessageKind that were printed.
+func (p *CountingPrinter) Counts() map[MessageKind]int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	counts := make(map[MessageKind]int, len(p.counts))
+	for kind, count := range p.counts {
+		counts[kind] = count
+	}
+
+	return counts
+}
+
+// Reset sets all the counts back to zero.
+func (p *CountingPrinter) Reset() {
+	p.mutex.Lock()
+	p.counts = make(map[MessageKind]int)
+	p.mutex.Unlock()
+}
+
+// Summary returns a summary of the number of warnings and errors that were printed.
+// Deprecation messages are counted as warnings.
+//
+// Example output:
+//
+//     completed with 3 warnings, 1 error
+func (p *CountingPrinter) Summary() string {
+	p.mutex.Lock()
+	warnings := p.counts[Warning] + p.counts[Deprecation]
+	errors := p.counts[Error]
+	p.mutex.Unlock()
+
+	var parts []string
+	if warnings > 0 {
+		parts = append(parts, pluralize(warnings, "warning", "warnings"))
+	}
+
+	if errors > 0 {
+		parts = append(parts, pluralize(errors, "error", "errors"))
+	}
+
+	if len(parts) == 0 {
+		return "completed successfully"
+	}
+
+	return "completed with " + strings.Join(parts, ", ")
+}
+
+// ExitCode returns an exit code for the messages that were printed.
+// This is 1 if any Error messages were printed, or 0 otherwise.
+func (p *CountingPrinter) ExitCode() int {
+	if p.Count(Error) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// promoteWarning converts a warning Message into an Error message with a note explaining why.
+// If the Message has a Diagnostic, the note is added to it. Otherwise, the note is appended to the text.
+func promoteWarning(message Message) Message {
+	message.kind = Error
+	if message.diagnostic != nil {
+		diagnostic := message.diagnostic.WithNote(werrorNote)
+		message.diagnostic = &diagnostic
+	} else {
+		message.format += " (%s)"
+		message.formatArgs = append(append([]interface{}(nil), message.formatArgs...), werrorNote)
+	}
+
+	return message
+}
@@ -0,0 +1,113 @@
+package clout
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCountingPrinter(t *testing.T) {
+	tests := map[string]struct {
+		expectedSummary  string
+		expectedExitCode int
+		expectedCounts   map[MessageKind]int
+		werror           bool
+		fn               func(v *Verbose)
+	}{
+		"Nothing": {
+			expectedSummary:  "completed successfully",
+			expectedExitCode: 0,
+			expectedCounts:   map[MessageKind]int{},
+			fn:               func(v *Verbose) {},
+		},
+		"Status Only": {
+			expectedSummary:  "completed successfully",
+			expectedExitCode: 0,
+			expectedCounts:   map[MessageKind]int{Status: 1, Info: 1},
+			fn: func(v *Verbose) {
+				v.Statusf("status")
+				v.Infof("info")
+			},
+		},
+		"Warnings And Errors": {
+			expectedSummary:  "completed with 3 warnings, 1 error",
+			expectedExitCode: 1,
+			expectedCounts:   map[MessageKind]int{Warning: 2, Deprecation: 1, Error: 1},
+			fn: func(v *Verbose) {
+				v.Warningf("a")
+				v.Warningf("b")
+				v.Deprecationf("c")
+				v.Errorf("d")
+			},
+		},
+		"Warnings As Errors": {
+			expectedSummary:  "completed with 3 errors",
+			expectedExitCode: 1,
+			expectedCounts:   map[MessageKind]int{Error: 3},
+			werror:           true,
+			fn: func(v *Verbose) {
+				v.Warningf("a")
+				v.Deprecationf("b")
+				v.Errorf("c")
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewCountingPrinter(&testPrinter{}).SetWarningsAsErrors(tc.werror)
+			tc.fn(&Verbose{enabled: true, printer: p})
+
+			if summary := p.Summary(); summary != tc.expectedSummary {
+				t.Fatalf("expected summary %q, got %q", tc.expectedSummary, summary)
+			}
+
+			if code := p.ExitCode(); code != tc.expectedExitCode {
+				t.Fatalf("expected exit code %d, got %d", tc.expectedExitCode, code)
+			}
+
+			diff := cmp.Diff(tc.expectedCounts, p.Counts())
+			if diff != "" {
+				t.Log("did not find expected counts; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestCountingPrinterWarningsAsErrors(t *testing.T) {
+	inner := &testPrinter{}
+	p := NewCountingPrinter(inner).SetWarningsAsErrors(true)
+	v := &Verbose{enabled: true, printer: p}
+
+	v.Warningf("unused %s", "variable")
+	v.WithDiagnostic(NewDiagnostic("main.go", 1, 1)).Warningf("unused variable")
+
+	if inner.messages[0].Kind() != Error {
+		t.Fatalf("expected warning to be promoted to an error, got kind %v", inner.messages[0].Kind())
+	}
+
+	expected := "unused variable (warnings are being treated as errors)"
+	if text := inner.messages[0].String(); text != expected {
+		t.Fatalf("expected %q, got %q", expected, text)
+	}
+
+	notes := inner.messages[1].Diagnostic().Notes()
+	if len(notes) != 1 || notes[0].String() != werrorNote {
+		t.Fatalf("expected diagnostic note %q, got %v", werrorNote, notes)
+	}
+
+	if text := inner.messages[1].String(); text != "unused variable" {
+		t.Fatalf("expected diagnostic message text to be unchanged, got %q", text)
+	}
+}
+
+func TestCountingPrinterReset(t *testing.T) {
+	p := NewCountingPrinter(&testPrinter{})
+	p.Print(New(Error, 0, "error"))
+	p.Reset()
+
+	if count := p.Count(Error); count != 0 {
+		t.Fatalf("expected count to be reset, got %d", count)
+	}
+}
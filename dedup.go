@@ -0,0 +1,293 @@
+package clout
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultDedupWindow is the default amount of time that repeated messages are suppressed for.
+const defaultDedupWindow = 5 * time.Second
+
+// maxRateLimitSites is the maximum number of call sites that a DedupPrinter keeps rate limit buckets for.
+const maxRateLimitSites = 1024
+
+// DedupPrinter is a PrinterInterface that suppresses repeated messages before passing them to another printer.
+//
+// When the same message (the same kind, name, text, and fields) is printed multiple times in a row, only the first
+// one is printed. Once a different message is printed, the window expires, or the printer is flushed, a
+// "(previous message repeated N times)" message is printed in place of the suppressed ones.
+//
+// The DedupPrinter can also limit how many messages are printed from the same call site with SetRateLimit.
+//
+// Example:
+//
+//     clout.SetPrinter(clout.NewDedupPrinter(clout.GetPrinter()).SetRateLimit(time.Second, 10))
+type DedupPrinter struct {
+	printer  PrinterInterface
+	window   time.Duration
+	interval time.Duration
+	burst    int
+	now      func() time.Time
+
+	mutex      sync.Mutex
+	last       Message
+	lastKey    string
+	lastTime   time.Time
+	suppressed int
+	sites      map[string]*rateLimitBucket
+	lastEvict  time.Time
+}
+
+// rateLimitBucket is a token bucket used to rate limit messages from a single call site.
+type rateLimitBucket struct {
+	tokens  float64
+	updated time.Time
+	dropped int
+	last    Message
+}
+
+// NewDedupPrinter creates a DedupPrinter that prints to another PrinterInterface.
+func NewDedupPrinter(printer PrinterInterface) *DedupPrinter {
+	return &DedupPrinter{
+		printer: printer,
+		window:  defaultDedupWindow,
+		now:     time.Now,
+		sites:   make(map[string]*rateLimitBucket),
+	}
+}
+
+// SetWindow sets the maximum amount of time that a repeated message will be suppressed for.
+// After the window expires, the number of suppressed messages is printed and the message is printed again.
+func (p *DedupPrinter) SetWindow(window time.Duration) *DedupPrinter {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.window = window
+	return p
+}
+
+// SetRateLimit limits the number of messages that can be printed from a single call site.
+// Up to burst messages can be printed at once, and one more can be printed after every interval.
+//
+// Call sites are identified by the source file and line of the Message caller. Messages without a caller (e.g. from
+// Verbose.AsWriter) are only grouped with identical messages. A burst of 0 disables rate limiting.
+//
+// Call sites that have not printed anything for long enough to refill their burst are forgotten. If there are still
+// too many call sites, the least recently used one is forgotten.
+func (p *DedupPrinter) SetRateLimit(interval time.Duration, burst int) *DedupPrinter {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.interval = interval
+	p.burst = burst
+	p.sites = make(map[string]*rateLimitBucket)
+	return p
+}
+
+func (p *DedupPrinter) Print(message Message) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Messages that were only enabled for a more verbose output are passed through without affecting the
+	// deduplication and rate limiting of the messages shown to the user.
+	if message.aboveVerbosity {
+		p.printer.Print(message)
+		return
+	}
+
+	now := p.now()
+	key := dedupKey(&message)
+
+	// Suppress repeated messages.
+	if key == p.lastKey && now.Sub(p.lastTime) < p.window {
+		p.suppressed++
+		return
+	}
+
+	p.printRepeated()
+	p.last = message
+	p.lastKey = key
+	p.lastTime = now
+
+	// Rate limit messages from the same call site.
+	if p.burst > 0 {
+		bucket := p.bucket(&message, now)
+		if bucket.tokens < 1 {
+			bucket.dropped++
+			bucket.last = message
+			p.lastKey = "" // Repeats of a dropped message should be dropped too, not reported as repeated.
+			return
+		}
+
+		bucket.tokens--
+		p.printDropped(bucket)
+	}
+
+	p.printer.Print(message)
+}
+
+// Capture returns the information that the wrapped PrinterInterface needs captured.
+// If rate limiting is enabled, the caller is also captured.
+func (p *DedupPrinter) Capture() Capture {
+	capture := captureOf(p.printer)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.burst > 0 {
+		capture |= CaptureCaller
+	}
+
+	return capture
+}
+
+// MaxVerbosity returns the highest MessageVerbosity that the wrapped PrinterInterface wants to receive.
+func (p *DedupPrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.printer)
+}
+
+// Flush prints the number of any suppressed messages, then flushes the wrapped PrinterInterface.
+func (p *DedupPrinter) Flush(ctx context.Context) error {
+	p.mutex.Lock()
+	p.printRepeated()
+	p.lastKey = ""
+	for _, bucket := range p.sites {
+		p.printDropped(bucket)
+	}
+	p.mutex.Unlock()
+
+	return FlushPrinter(ctx, p.printer)
+}
+
+// bucket returns the refilled rate limit bucket for the call site of a Message.
+// This must be called while holding the mutex.
+func (p *DedupPrinter) bucket(message *Message, now time.Time) *rateLimitBucket {
+	site := "message:" + dedupKey(message)
+	if caller := message.Caller(); caller.File != "" {
+		site = "caller:" + caller.File + ":" + strconv.Itoa(caller.Line)
+	}
+
+	bucket, ok := p.sites[site]
+	if !ok {
+		p.evictSites(now)
+		bucket = &rateLimitBucket{tokens: float64(p.burst), updated: now}
+		p.sites[site] = bucket
+	}
+
+	if p.interval > 0 {
+		bucket.tokens += float64(now.Sub(bucket.updated)) / float64(p.interval)
+		if bucket.tokens > float64(p.burst) {
+			bucket.tokens = float64(p.burst)
+		}
+	}
+
+	bucket.updated = now
+	return bucket
+}
+
+// evictSites forgets rate limit buckets so that the number of call sites doesn't grow forever.
+// This must be called while holding the mutex.
+func (p *DedupPrinter) evictSites(now time.Time) {
+	// A bucket that has refilled behaves the same as a new one, so it can be forgotten.
+	// This only checks once per refill, since every bucket needs to be visited.
+	if refill := p.interval * time.Duration(p.burst); p.interval > 0 && now.Sub(p.lastEvict) >= refill {
+		p.lastEvict = now
+		for site, bucket := range p.sites {
+			if bucket.dropped == 0 && now.Sub(bucket.updated) >= refill {
+				delete(p.sites, site)
+			}
+		}
+	}
+
+	if len(p.sites) < maxRateLimitSites {
+		return
+	}
+
+	var oldestSite string
+	var oldest *rateLimitBucket
+	for site, bucket := range p.sites {
+		if oldest == nil || bucket.updated.Before(oldest.updated) {
+			oldestSite, oldest = site, bucket
+		}
+	}
+
+	p.printDropped(oldest)
+	delete(p.sites, oldestSite)
+}
+
+// printRepeated prints the number of times that the last message was suppressed.
+// This must be called while holding the mutex.
+func (p *DedupPrinter) printRepeated() {
+	if p.suppressed == 0 {
+		return
+	}
+
+	p.printer.Print(New(p.last.Kind(), p.last.Verbosity(),
+		"(previous message repeated %s)", pluralize(p.suppressed, "time", "times")))
+
+	p.suppressed = 0
+}
+
+// printDropped prints the number of messages that were dropped by a rate limit bucket.
+// This must be called while holding the mutex.
+func (p *DedupPrinter) printDropped(bucket *rateLimitBucket) {
+	if bucket.dropped == 0 {
+		return
+	}
+
+	p.printer.Print(New(bucket.last.Kind(), bucket.last.Verbosity(),
+		"(%s suppressed)", pluralize(bucket.dropped, "similar message", "similar messages")))
+
+	bucket.dropped = 0
+}
+
+// dedupKey returns a string that is identical for identical messages.
+func dedupKey(message *Message) string {
+	return strconv.Itoa(int(message.Kind())) + "\x00" +
+		message.Name() + "\x00" +
+		message.Code() + "\x00" +
+		formatText(message, false) +
+		formatFields(message, false)
+}
+
+var onceKeys sync.Map
+
+// ResetOnce forgets the keys used with Verbose.Once, so their messages will be printed again.
+// This is intended for tests, or for long-running programs that want to repeat one-shot warnings after reloading.
+func ResetOnce() {
+	onceKeys.Range(func(key, value interface{}) bool {
+		onceKeys.Delete(key)
+		return true
+	})
+}
+
+// oncePrinter is a PrinterInterface that only prints the first message for a key.
+type oncePrinter struct {
+	printer PrinterInterface
+	key     string
+}
+
+func (p oncePrinter) Print(message Message) {
+	if message.aboveVerbosity {
+		p.printer.Print(message)
+		return
+	}
+
+	if _, seen := onceKeys.LoadOrStore(p.key, true); !seen {
+		p.printer.Print(message)
+	}
+}
+
+// Capture returns the information that the wrapped PrinterInterface needs captured.
+func (p oncePrinter) Capture() Capture {
+	return captureOf(p.printer)
+}
+
+// MaxVerbosity returns the highest MessageVerbosity that the wrapped PrinterInterface wants to receive.
+func (p oncePrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.printer)
+}
+
+// Flush flushes the wrapped PrinterInterface.
+func (p oncePrinter) Flush(ctx context.Context) error {
+	return FlushPrinter(ctx, p.printer)
+}
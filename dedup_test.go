@@ -0,0 +1,225 @@
+package clout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDedupPrinter(t *testing.T) {
+	tests := map[string]struct {
+		expected []string
+		init     func(p *DedupPrinter)
+		fn       func(p *DedupPrinter, clock *time.Time)
+	}{
+		"Repeated": {
+			expected: []string{"a", "(previous message repeated 4,999 times)", "b"},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				for i := 0; i < 5000; i++ {
+					p.Print(New(Warning, 2, "a"))
+				}
+
+				p.Print(New(Warning, 2, "b"))
+			},
+		},
+		"Repeated Once": {
+			expected: []string{"a", "(previous message repeated 1 time)", "b"},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Warning, 2, "a"))
+				p.Print(New(Warning, 2, "a"))
+				p.Print(New(Warning, 2, "b"))
+			},
+		},
+		"Different Args": {
+			expected: []string{"a 1", "a 2"},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Warning, 2, "a %d", 1))
+				p.Print(New(Warning, 2, "a %d", 2))
+			},
+		},
+		"Different Kinds": {
+			expected: []string{"a", "a"},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Warning, 2, "a"))
+				p.Print(New(Error, 2, "a"))
+			},
+		},
+		"Window Expired": {
+			expected: []string{"a", "(previous message repeated 2 times)", "a"},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Warning, 2, "a"))
+				p.Print(New(Warning, 2, "a"))
+				p.Print(New(Warning, 2, "a"))
+				*clock = clock.Add(defaultDedupWindow)
+				p.Print(New(Warning, 2, "a"))
+			},
+		},
+		"Flush": {
+			expected: []string{"a", "(previous message repeated 1 time)", "a"},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Warning, 2, "a"))
+				p.Print(New(Warning, 2, "a"))
+				_ = p.Flush(context.Background())
+				p.Print(New(Warning, 2, "a"))
+			},
+		},
+		"Rate Limit": {
+			expected: []string{"1", "2", "(2 similar messages suppressed)", "5"},
+			init: func(p *DedupPrinter) {
+				p.SetRateLimit(time.Second, 2)
+			},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				caller := Caller{File: "main.go", Line: 1}
+				for i := 1; i <= 4; i++ {
+					p.Print(New(Info, 2, "%d", i).WithCaller(caller))
+				}
+
+				*clock = clock.Add(time.Second)
+				p.Print(New(Info, 2, "%d", 5).WithCaller(caller))
+			},
+		},
+		"Rate Limit Per Call Site": {
+			expected: []string{"1", "2"},
+			init: func(p *DedupPrinter) {
+				p.SetRateLimit(time.Second, 1)
+			},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Info, 2, "%d", 1).WithCaller(Caller{File: "main.go", Line: 1}))
+				p.Print(New(Info, 2, "%d", 2).WithCaller(Caller{File: "main.go", Line: 2}))
+			},
+		},
+		"Rate Limit Flush": {
+			expected: []string{"1", "(1 similar message suppressed)"},
+			init: func(p *DedupPrinter) {
+				p.SetRateLimit(time.Second, 1)
+			},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				caller := Caller{File: "main.go", Line: 1}
+				p.Print(New(Info, 2, "%d", 1).WithCaller(caller))
+				p.Print(New(Info, 2, "%d", 2).WithCaller(caller))
+				_ = p.Flush(context.Background())
+			},
+		},
+		"Rate Limit Same Line In Different Files": {
+			expected: []string{"1", "2"},
+			init: func(p *DedupPrinter) {
+				p.SetRateLimit(time.Second, 1)
+			},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Info, 2, "%d", 1).WithCaller(Caller{File: "foo/util.go", Line: 10}))
+				p.Print(New(Info, 2, "%d", 2).WithCaller(Caller{File: "bar/util.go", Line: 10}))
+			},
+		},
+		"Rate Limit Without Caller": {
+			expected: []string{"a", "b", "c", "(1 similar message suppressed)"},
+			init: func(p *DedupPrinter) {
+				p.SetRateLimit(time.Second, 1)
+			},
+			fn: func(p *DedupPrinter, clock *time.Time) {
+				p.Print(New(Info, 2, "%s", "a"))
+				p.Print(New(Info, 2, "%s", "b"))
+				p.Print(New(Info, 2, "%s", "a"))
+				p.Print(New(Info, 2, "%s", "c"))
+				_ = p.Flush(context.Background())
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+			inner := &testPrinter{}
+			p := NewDedupPrinter(inner)
+			p.now = func() time.Time { return clock }
+			if tc.init != nil {
+				tc.init(p)
+			}
+
+			tc.fn(p, &clock)
+
+			var got []string
+			for _, message := range inner.messages {
+				got = append(got, message.String())
+			}
+
+			diff := cmp.Diff(tc.expected, got)
+			if diff != "" {
+				t.Log("did not find expected messages; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestDedupPrinterEvictsSites(t *testing.T) {
+	tests := map[string]struct {
+		interval time.Duration
+		expected int
+	}{
+		"Refilled": {
+			interval: time.Second,
+			expected: 1,
+		},
+		"Too Many": {
+			interval: 0,
+			expected: maxRateLimitSites,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+			p := NewDedupPrinter(&testPrinter{}).SetRateLimit(tc.interval, 2)
+			p.now = func() time.Time { return clock }
+
+			// Messages without a caller (e.g. from a subprocess) each have their own call site.
+			for i := 0; i < maxRateLimitSites*2; i++ {
+				p.Print(New(Info, 2, "line %d", i))
+			}
+
+			clock = clock.Add(time.Minute)
+			p.Print(New(Info, 2, "last"))
+
+			if len(p.sites) != tc.expected {
+				t.Fatalf("expected %d call sites to be kept, got %d", tc.expected, len(p.sites))
+			}
+		})
+	}
+}
+
+func TestDedupPrinterCapture(t *testing.T) {
+	p := NewDedupPrinter(&testCapturingPrinter{capture: CaptureTime})
+	if capture := p.Capture(); capture != CaptureTime {
+		t.Fatalf("expected CaptureTime, got %v", capture)
+	}
+
+	p.SetRateLimit(time.Second, 1)
+	if capture := p.Capture(); capture != CaptureTime|CaptureCaller {
+		t.Fatalf("expected CaptureTime|CaptureCaller, got %v", capture)
+	}
+}
+
+func TestVerboseOnce(t *testing.T) {
+	ResetOnce()
+	t.Cleanup(ResetOnce)
+
+	p := &testPrinter{}
+	v := &Verbose{enabled: true, printer: p}
+
+	for i := 0; i < 3; i++ {
+		v.Once("TestVerboseOnce/a").Warningf("a")
+		v.Once("TestVerboseOnce/b").Warningf("b")
+	}
+
+	if len(p.messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(p.messages))
+	}
+
+	ResetOnce()
+	v.Once("TestVerboseOnce/a").Warningf("a")
+	if len(p.messages) != 3 {
+		t.Fatalf("expected message to be printed again after ResetOnce, got %d messages", len(p.messages))
+	}
+}
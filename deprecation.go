@@ -0,0 +1,254 @@
+package clout
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// DeprecationUsage is a record of a deprecated feature that was used while the program was running.
+type DeprecationUsage struct {
+	// ID is the unique identifier of the deprecated feature.
+	ID string
+
+	// Since is the version that the feature was deprecated in.
+	Since string
+
+	// RemovedIn is the version that the feature will be (or was) removed in.
+	RemovedIn string
+
+	// Message is the formatted deprecation message.
+	Message string
+
+	// Count is the number of times that the feature was used.
+	Count int
+}
+
+var deprecationMutex sync.Mutex
+var deprecationUsages []*DeprecationUsage
+var deprecationIndex = make(map[string]*DeprecationUsage)
+var toolVersion string
+
+// SetToolVersion sets the version of the program.
+// This is compared against the removedIn version of Deprecated features to decide if they should be errors.
+func SetToolVersion(version string) {
+	deprecationMutex.Lock()
+	toolVersion = version
+	deprecationMutex.Unlock()
+}
+
+// GetToolVersion gets the version of the program set with SetToolVersion.
+func GetToolVersion() string {
+	deprecationMutex.Lock()
+	defer deprecationMutex.Unlock()
+	return toolVersion
+}
+
+// Deprecated records the use of a deprecated feature, printing a V(1) Deprecation message the first time it is used.
+// Every use is recorded, and can be listed with Deprecations or PrintDeprecationSummary.
+//
+// The since and removedIn versions are included in the message if they are not empty. If the tool version (see
+// SetToolVersion) is at or past the removedIn version, the message is printed as an Error instead.
+//
+// Example:
+//
+//     clout.Deprecated("legacy-config", "1.4", "2.0", "the %s file format is deprecated", "ini")
+//     // -> deprecated: the ini file format is deprecated (since 1.4, will be removed in 2.0)
+func Deprecated(id string, since string, removedIn string, format string, args ...interface{}) {
+	deprecationMutex.Lock()
+	usage, seen := deprecationIndex[id]
+	if !seen {
+		usage = &DeprecationUsage{ID: id, Since: since, RemovedIn: removedIn}
+		deprecationIndex[id] = usage
+		deprecationUsages = append(deprecationUsages, usage)
+	}
+
+	usage.Count++
+	removed := removedIn != "" && toolVersion != "" && compareVersions(toolVersion, removedIn) >= 0
+	deprecationMutex.Unlock()
+
+	if seen {
+		return
+	}
+
+	v := VDepth(1, 1).WithCallDepth(1)
+	message := v.message(Deprecation, format, args)
+	if removed {
+		message.kind = Error
+		message.format += " (removed in %s)"
+		message.formatArgs = append(append([]interface{}(nil), args...), removedIn)
+	} else if suffix, suffixArgs := deprecationVersions(since, removedIn); suffix != "" {
+		message.format += suffix
+		message.formatArgs = append(append([]interface{}(nil), args...), suffixArgs...)
+	}
+
+	deprecationMutex.Lock()
+	usage.Message = formatText(&message, false)
+	deprecationMutex.Unlock()
+
+	if v.Enabled() {
+		v.printer.Print(message)
+		exitOnBrokenPipe()
+	}
+}
+
+// Deprecations returns the deprecated features that were used, in the order they were first used.
+func Deprecations() []DeprecationUsage {
+	deprecationMutex.Lock()
+	defer deprecationMutex.Unlock()
+
+	usages := make([]DeprecationUsage, len(deprecationUsages))
+	for i, usage := range deprecationUsages {
+		usages[i] = *usage
+	}
+
+	return usages
+}
+
+// PrintDeprecationSummary prints a V(1) Deprecation message with a table of the deprecated features that were used.
+// If no deprecated features were used, nothing is printed.
+//
+// This can be deferred in main, or registered with RegisterExitHook:
+//
+//     clout.RegisterExitHook(clout.PrintDeprecationSummary)
+func PrintDeprecationSummary() {
+	v := V(1)
+	usages := Deprecations()
+	if !v.Enabled() || len(usages) == 0 {
+		return
+	}
+
+	var table strings.Builder
+	writer := tabwriter.NewWriter(&table, 0, 4, 2, ' ', 0)
+	_, _ = writer.Write([]byte("  ID\tSINCE\tREMOVED IN\tUSES\n"))
+	for _, usage := range usages {
+		_, _ = writer.Write([]byte("  " + usage.ID + "\t" + orDash(usage.Since) + "\t" + orDash(usage.RemovedIn) +
+			"\t" + strconv.Itoa(usage.Count) + "\n"))
+	}
+
+	_ = writer.Flush()
+
+	features := "features were"
+	if len(usages) == 1 {
+		features = "feature was"
+	}
+
+	v.Deprecationf("%d deprecated %s used:\n%s", len(usages), features, strings.TrimSuffix(table.String(), "\n"))
+}
+
+// resetDeprecations forgets all recorded uses of deprecated features.
+func resetDeprecations() {
+	deprecationMutex.Lock()
+	deprecationUsages = nil
+	deprecationIndex = make(map[string]*DeprecationUsage)
+	deprecationMutex.Unlock()
+}
+
+// deprecationVersions returns a format string suffix and arguments describing the versions of a deprecation.
+func deprecationVersions(since string, removedIn string) (string, []interface{}) {
+	switch {
+	case since != "" && removedIn != "":
+		return " (since %s, will be removed in %s)", []interface{}{since, removedIn}
+	case since != "":
+		return " (since %s)", []interface{}{since}
+	case removedIn != "":
+		return " (will be removed in %s)", []interface{}{removedIn}
+	default:
+		return "", nil
+	}
+}
+
+// compareVersions compares two dot-separated version numbers (e.g. "v1.2.3").
+// A leading "v" and any build metadata (e.g. "+abc") are ignored, and missing components are zero.
+//
+// Pre-releases are ordered like semantic versions: "2.0.0-rc1" is less than "2.0.0", and pre-release identifiers are
+// compared one at a time (e.g. "2.0.0-alpha" < "2.0.0-alpha.1" < "2.0.0-beta" < "2.0.0-rc.2" < "2.0.0-rc.10").
+//
+// This returns a negative number if a < b, zero if a == b, and a positive number if a > b.
+func compareVersions(a string, b string) int {
+	partsA, preA := versionParts(a)
+	partsB, preB := versionParts(b)
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA = partsA[i]
+		}
+
+		if i < len(partsB) {
+			numB = partsB[i]
+		}
+
+		if numA != numB {
+			return numA - numB
+		}
+	}
+
+	// A version without a pre-release is greater than the same version with one.
+	switch {
+	case preA == "" && preB == "":
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+
+	return comparePreReleases(preA, preB)
+}
+
+// comparePreReleases compares the pre-release suffixes of two versions (e.g. "rc.1").
+func comparePreReleases(a string, b string) int {
+	idsA := strings.Split(a, ".")
+	idsB := strings.Split(b, ".")
+	for i := 0; i < len(idsA) && i < len(idsB); i++ {
+		numA, errA := strconv.Atoi(idsA[i])
+		numB, errB := strconv.Atoi(idsB[i])
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				return numA - numB
+			}
+		case errA == nil:
+			return -1 // Numeric identifiers are less than alphanumeric ones.
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(idsA[i], idsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return len(idsA) - len(idsB)
+}
+
+// versionParts splits a version number into its numeric components and its pre-release suffix.
+func versionParts(version string) ([]int, string) {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+
+	var preRelease string
+	if i := strings.Index(version, "-"); i >= 0 {
+		version, preRelease = version[:i], version[i+1:]
+	}
+
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		num, _ := strconv.Atoi(part)
+		parts = append(parts, num)
+	}
+
+	return parts, preRelease
+}
+
+// orDash returns the string, or "-" if it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,158 @@
+package clout
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testDeprecation struct {
+	Kind MessageKind
+	Text string
+}
+
+func TestDeprecated(t *testing.T) {
+	tests := map[string]struct {
+		expected []testDeprecation
+		version  string
+		fn       func()
+	}{
+		"Once Per ID": {
+			expected: []testDeprecation{
+				{Kind: Deprecation, Text: "a is deprecated (since 1.0, will be removed in 2.0)"},
+				{Kind: Deprecation, Text: "b is deprecated"},
+			},
+			fn: func() {
+				for i := 0; i < 3; i++ {
+					Deprecated("a", "1.0", "2.0", "%s is deprecated", "a")
+					Deprecated("b", "", "", "%s is deprecated", "b")
+				}
+			},
+		},
+		"Since Only": {
+			expected: []testDeprecation{{Kind: Deprecation, Text: "a is deprecated (since 1.0)"}},
+			fn:       func() { Deprecated("a", "1.0", "", "a is deprecated") },
+		},
+		"Removed In Only": {
+			expected: []testDeprecation{{Kind: Deprecation, Text: "a is deprecated (will be removed in 2.0)"}},
+			fn:       func() { Deprecated("a", "", "2.0", "a is deprecated") },
+		},
+		"Before Removal": {
+			expected: []testDeprecation{{Kind: Deprecation, Text: "a is deprecated (since 1.0, will be removed in 2.0)"}},
+			version:  "v1.9.3",
+			fn:       func() { Deprecated("a", "1.0", "2.0", "a is deprecated") },
+		},
+		"Release Candidate": {
+			expected: []testDeprecation{{Kind: Deprecation, Text: "a is deprecated (since 1.0, will be removed in 2.0)"}},
+			version:  "v2.0.0-rc1",
+			fn:       func() { Deprecated("a", "1.0", "2.0", "a is deprecated") },
+		},
+		"Escalated": {
+			expected: []testDeprecation{{Kind: Error, Text: "a is deprecated (removed in 2.0)"}},
+			version:  "v2.0.0",
+			fn:       func() { Deprecated("a", "1.0", "2.0", "a is deprecated") },
+		},
+		"Summary": {
+			expected: []testDeprecation{
+				{Kind: Deprecation, Text: "a is deprecated"},
+				{Kind: Deprecation, Text: "long-id is deprecated (since 1.0)"},
+				{Kind: Deprecation, Text: "2 deprecated features were used:\n" +
+					"  ID       SINCE  REMOVED IN  USES\n" +
+					"  a        -      -           2\n" +
+					"  long-id  1.0    -           1"},
+			},
+			fn: func() {
+				Deprecated("a", "", "", "a is deprecated")
+				Deprecated("a", "", "", "a is deprecated")
+				Deprecated("long-id", "1.0", "", "long-id is deprecated")
+				PrintDeprecationSummary()
+			},
+		},
+		"Summary Empty": {
+			expected: nil,
+			fn:       PrintDeprecationSummary,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetGlobals(t)
+			resetDeprecations()
+			defer resetDeprecations()
+			defer SetToolVersion("")
+
+			p := &testPrinter{}
+			SetPrinter(p)
+			SetToolVersion(tc.version)
+
+			tc.fn()
+
+			var got []testDeprecation
+			for _, message := range p.messages {
+				got = append(got, testDeprecation{Kind: message.Kind(), Text: message.String()})
+			}
+
+			diff := cmp.Diff(tc.expected, got)
+			if diff != "" {
+				t.Log("did not find expected Message; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	resetGlobals(t)
+	resetDeprecations()
+	defer resetDeprecations()
+
+	SetPrinter(&testPrinter{})
+	SetVerbosity(0)
+
+	Deprecated("a", "1.0", "2.0", "a is deprecated")
+	Deprecated("a", "1.0", "2.0", "a is deprecated")
+
+	expected := []DeprecationUsage{{
+		ID:        "a",
+		Since:     "1.0",
+		RemovedIn: "2.0",
+		Message:   "a is deprecated (since 1.0, will be removed in 2.0)",
+		Count:     2,
+	}}
+
+	diff := cmp.Diff(expected, Deprecations())
+	if diff != "" {
+		t.Log("did not find expected DeprecationUsage; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := map[string]struct {
+		a, b     string
+		expected int
+	}{
+		"Equal":                     {a: "1.2.3", b: "v1.2.3", expected: 0},
+		"Missing Parts":             {a: "2", b: "2.0.0", expected: 0},
+		"Less":                      {a: "1.9", b: "1.10", expected: -1},
+		"Greater":                   {a: "2.0.1", b: "2.0", expected: 1},
+		"Pre-release":               {a: "2.0.0-rc1", b: "2.0", expected: -1},
+		"Pre-release Of Older":      {a: "2.0.0-rc1", b: "1.9", expected: 1},
+		"Pre-release Equal":         {a: "v2.0.0-rc.1", b: "2.0.0-rc.1", expected: 0},
+		"Pre-release Numeric":       {a: "2.0.0-rc.2", b: "2.0.0-rc.10", expected: -1},
+		"Pre-release Alphanumeric":  {a: "2.0.0-alpha", b: "2.0.0-beta", expected: -1},
+		"Pre-release Numeric First": {a: "2.0.0-1", b: "2.0.0-alpha", expected: -1},
+		"Pre-release Longer":        {a: "2.0.0-alpha", b: "2.0.0-alpha.1", expected: -1},
+		"Build Metadata":            {a: "1.0.0+abc", b: "1.0.1", expected: -1},
+		"Build Metadata Ignored":    {a: "1.0.0+abc", b: "1.0.0", expected: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := compareVersions(tc.a, tc.b)
+			if (got < 0) != (tc.expected < 0) || (got > 0) != (tc.expected > 0) {
+				t.Fatalf("expected compareVersions(%q, %q) to have the sign of %d, got %d", tc.a, tc.b, tc.expected, got)
+			}
+		})
+	}
+}
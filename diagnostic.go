@@ -0,0 +1,210 @@
+package clout
+
+import (
+	"strconv"
+	"strings"
+
+	"go.eth-p.dev/clout/pkg/color"
+)
+
+// Diagnostic is a compiler-style location in a source file that a Message refers to.
+//
+// When colors are enabled, the default Printer renders it like rustc or clang would:
+//
+//     error: unknown key "nmae"
+//       --> config.yaml:12:5
+//        |
+//     12 |     nmae: example
+//        |     ^~~~
+//        = help: did you mean "name"?
+//
+// When colors are disabled, it degrades to a single "file:line:col: error: message" line.
+type Diagnostic struct {
+	file   string
+	line   int
+	column int
+	length int
+	source string
+	notes  []DiagnosticNote
+}
+
+// DiagnosticNote is an extra note (e.g. "note:" or "help:") attached to a Diagnostic.
+type DiagnosticNote struct {
+	label  string
+	format string
+	args   []interface{}
+}
+
+// Label returns the label of the note (e.g. "note" or "help").
+func (n DiagnosticNote) Label() string {
+	return n.label
+}
+
+// String formats the note and returns its string.
+func (n DiagnosticNote) String() string {
+	return formatText(&Message{format: n.format, formatArgs: n.args}, false)
+}
+
+// NewDiagnostic creates a Diagnostic for a location in a source file.
+// The line and column are 1-based, and can be zero if they are not known.
+// Columns are counted in characters, not bytes.
+func NewDiagnostic(file string, line int, column int) Diagnostic {
+	return Diagnostic{
+		file:   file,
+		line:   line,
+		column: column,
+		length: 1,
+	}
+}
+
+// WithSpan creates a copy of the Diagnostic that underlines a span of columns, starting at the column.
+func (d Diagnostic) WithSpan(length int) Diagnostic {
+	if length < 1 {
+		length = 1
+	}
+
+	d.length = length
+	return d
+}
+
+// WithSource creates a copy of the Diagnostic with the text of the source line.
+// If the source line is provided, it will be printed with the span underlined.
+func (d Diagnostic) WithSource(line string) Diagnostic {
+	d.source = strings.TrimRight(line, "\r\n")
+	return d
+}
+
+// WithNote creates a copy of the Diagnostic with an additional "note:" line.
+func (d Diagnostic) WithNote(format string, args ...interface{}) Diagnostic {
+	return d.withNote("note", format, args)
+}
+
+// WithHelp creates a copy of the Diagnostic with an additional "help:" line.
+func (d Diagnostic) WithHelp(format string, args ...interface{}) Diagnostic {
+	return d.withNote("help", format, args)
+}
+
+// withNote creates a copy of the Diagnostic with an additional labelled note.
+func (d Diagnostic) withNote(label string, format string, args []interface{}) Diagnostic {
+	notes := make([]DiagnosticNote, len(d.notes), len(d.notes)+1)
+	copy(notes, d.notes)
+	d.notes = append(notes, DiagnosticNote{label: label, format: format, args: args})
+	return d
+}
+
+// File returns the path of the source file.
+func (d Diagnostic) File() string {
+	return d.file
+}
+
+// Line returns the 1-based line number, or zero if it is not known.
+func (d Diagnostic) Line() int {
+	return d.line
+}
+
+// Column returns the 1-based column number, or zero if it is not known.
+func (d Diagnostic) Column() int {
+	return d.column
+}
+
+// Length returns the number of columns in the underlined span.
+func (d Diagnostic) Length() int {
+	return d.length
+}
+
+// Source returns the text of the source line.
+func (d Diagnostic) Source() string {
+	return d.source
+}
+
+// Notes returns the notes attached to the Diagnostic.
+func (d Diagnostic) Notes() []DiagnosticNote {
+	return d.notes
+}
+
+// Location returns the location of the Diagnostic as a "file:line:col" string.
+// The line and column are omitted if they are not known.
+func (d Diagnostic) Location() string {
+	location := d.file
+	if d.line > 0 {
+		location += ":" + strconv.Itoa(d.line)
+		if d.column > 0 {
+			location += ":" + strconv.Itoa(d.column)
+		}
+	}
+
+	return location
+}
+
+// diagnosticGutterStyle is the color.Style applied to the "-->" and "|" gutter of a Diagnostic.
+var diagnosticGutterStyle = color.Foreground(color.Blue).Bold(true)
+
+// diagnosticNoteStyle is the color.Style applied to the labels of DiagnosticNote lines.
+var diagnosticNoteStyle = color.Plain().Bold(true)
+
+// formatDiagnosticLines formats the location, source line, and notes of a Diagnostic into lines of text.
+// The underline is styled with accentStyle.
+func formatDiagnosticLines(d *Diagnostic, accentStyle color.Style) []string {
+	gutterWidth := 1
+	if d.source != "" && d.line > 0 {
+		gutterWidth = len(strconv.Itoa(d.line))
+	}
+
+	gutter := strings.Repeat(" ", gutterWidth+1)
+	lines := []string{gutter[1:] + diagnosticGutterStyle.Apply("-->") + " " + d.Location()}
+
+	// Print the source line with an underline.
+	if d.source != "" && d.line > 0 {
+		bar := diagnosticGutterStyle.Apply("|")
+		lines = append(lines,
+			gutter+bar,
+			diagnosticGutterStyle.Apply(strconv.Itoa(d.line)+" |")+" "+d.source,
+		)
+
+		if d.column > 0 {
+			underline := "^" + strings.Repeat("~", d.length-1)
+			lines = append(lines, gutter+bar+" "+diagnosticIndent(d.source, d.column)+accentStyle.Apply(underline))
+		}
+	}
+
+	// Print the notes.
+	for _, note := range d.notes {
+		label := diagnosticNoteStyle.Apply(note.label + ":")
+		lines = append(lines, gutter+diagnosticGutterStyle.Apply("=")+" "+label+" "+note.String())
+	}
+
+	return lines
+}
+
+// formatDiagnosticPlainLines formats the notes of a Diagnostic into "file:line:col: label: text" lines.
+func formatDiagnosticPlainLines(d *Diagnostic) []string {
+	lines := make([]string, 0, len(d.notes))
+	for _, note := range d.notes {
+		lines = append(lines, d.Location()+": "+note.label+": "+note.String())
+	}
+
+	return lines
+}
+
+// diagnosticIndent creates whitespace that aligns with a 1-based column of a source line.
+// Tabs in the source line are preserved, so the alignment is correct regardless of the tab width.
+func diagnosticIndent(source string, column int) string {
+	var sb strings.Builder
+	for i, r := range []rune(source) {
+		if i >= column-1 {
+			break
+		}
+
+		if r == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+
+	for i := len([]rune(source)); i < column-1; i++ {
+		sb.WriteRune(' ')
+	}
+
+	return sb.String()
+}
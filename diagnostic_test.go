@@ -0,0 +1,114 @@
+package clout
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.eth-p.dev/clout/pkg/color"
+)
+
+// ansiPattern matches ANSI SGR escape sequences.
+var ansiPattern = regexp.MustCompile("\x1B\\[[0-9;]*m")
+
+func TestDiagnostic(t *testing.T) {
+	tests := map[string]struct {
+		diagnostic Diagnostic
+		colors     bool
+		timestamp  TimestampFormat
+		expected   string
+	}{
+		"Plain": {
+			diagnostic: NewDiagnostic("config.yaml", 12, 5).
+				WithSource("    nmae: example").
+				WithHelp("did you mean %q?", "name"),
+			colors: false,
+			expected: "config.yaml:12:5: error: unknown key\n" +
+				"config.yaml:12:5: help: did you mean \"name\"?\n",
+		},
+		"Plain Without Column": {
+			diagnostic: NewDiagnostic("config.yaml", 12, 0),
+			colors:     false,
+			expected:   "config.yaml:12: error: unknown key\n",
+		},
+		"Plain With Timestamp": {
+			diagnostic: NewDiagnostic("config.yaml", 12, 5),
+			colors:     false,
+			timestamp:  AbsoluteTimestamp,
+			expected:   "2021-06-01 12:34:56.789 config.yaml:12:5: error: unknown key\n",
+		},
+		"Rich": {
+			diagnostic: NewDiagnostic("config.yaml", 12, 5).
+				WithSpan(4).
+				WithSource("    nmae: example").
+				WithNote("keys are case-sensitive").
+				WithHelp("did you mean %q?", "name"),
+			colors: true,
+			expected: "error: unknown key\n" +
+				"  --> config.yaml:12:5\n" +
+				"   |\n" +
+				"12 |     nmae: example\n" +
+				"   |     ^~~~\n" +
+				"   = note: keys are case-sensitive\n" +
+				"   = help: did you mean \"name\"?\n",
+		},
+		"Rich With Tabs": {
+			diagnostic: NewDiagnostic("main.go", 3, 3).
+				WithSource("\t\tx := 1"),
+			colors: true,
+			expected: "error: unknown key\n" +
+				" --> main.go:3:3\n" +
+				"  |\n" +
+				"3 | \t\tx := 1\n" +
+				"  | \t\t^\n",
+		},
+		"Rich Without Source": {
+			diagnostic: NewDiagnostic("config.yaml", 0, 0),
+			colors:     true,
+			expected: "error: unknown key\n" +
+				" --> config.yaml\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			output := OutputFromWriter(buf).
+				WithPrefix("error:", color.Foreground(color.Red).Bold(true)).
+				WithColors(tc.colors).
+				WithTimestamp(tc.timestamp)
+
+			printer := Map(func(m Message) Message {
+				return m.WithTime(time.Date(2021, 6, 1, 12, 34, 56, 789000000, time.Local))
+			}, NewPrinter().SetOutput(output))
+
+			v := Verbose{printer: printer, enabled: true}
+			v.WithDiagnostic(tc.diagnostic).Errorf("unknown key")
+
+			got := ansiPattern.ReplaceAllString(buf.String(), "")
+			if tc.expected != got {
+				t.Fatalf("expected:\n%s\ngot:\n%s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDiagnosticLocation(t *testing.T) {
+	tests := map[string]struct {
+		diagnostic Diagnostic
+		expected   string
+	}{
+		"File":   {diagnostic: NewDiagnostic("a.yaml", 0, 0), expected: "a.yaml"},
+		"Line":   {diagnostic: NewDiagnostic("a.yaml", 1, 0), expected: "a.yaml:1"},
+		"Column": {diagnostic: NewDiagnostic("a.yaml", 1, 2), expected: "a.yaml:1:2"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.diagnostic.Location(); got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
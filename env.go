@@ -0,0 +1,58 @@
+package clout
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Environment variables used to configure the default global printer and verbosity.
+//
+// These are applied when the package is initialized, and have the lowest precedence:
+//
+//   1. Explicit calls to SetPrinter (the global printer will not be replaced by flags or environment variables).
+//   2. Command-line flags registered with RegisterFlags.
+//   3. Environment variables.
+//
+// Calls to SetVerbosity always change the verbosity, even if it was set by a flag or environment variable.
+const (
+	// EnvVerbosity is the environment variable for the default verbosity (e.g. "CLOUT_VERBOSITY=4").
+	EnvVerbosity = "CLOUT_VERBOSITY"
+
+	// EnvFormat is the environment variable for the default OutputFormat (e.g. "CLOUT_FORMAT=json").
+	EnvFormat = "CLOUT_FORMAT"
+)
+
+// applyEnvironment configures the default global printer and verbosity from environment variables.
+// Invalid values are ignored, and returned as errors.
+func applyEnvironment(lookupEnv func(key string) (string, bool)) []error {
+	var errs []error
+
+	if value, exists := lookupEnv(EnvVerbosity); exists && value != "" {
+		verbosity, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid $%s: %w", EnvVerbosity, err))
+		} else {
+			SetVerbosity(MessageVerbosity(verbosity))
+		}
+	}
+
+	if value, exists := lookupEnv(EnvFormat); exists && value != "" {
+		format, err := ParseOutputFormat(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid $%s: %w", EnvFormat, err))
+		} else {
+			updateSettings(func(s *settings) { s.format = format })
+		}
+	}
+
+	return errs
+}
+
+// applyDefaultEnvironment configures the default global printer and verbosity from the process environment.
+// Invalid values are reported as warnings.
+func applyDefaultEnvironment() {
+	for _, err := range applyEnvironment(os.LookupEnv) {
+		V(0).Warningf("%v", err)
+	}
+}
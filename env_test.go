@@ -0,0 +1,109 @@
+package clout
+
+import (
+	"flag"
+	"testing"
+)
+
+// testEnv creates a lookupEnv function for a map of environment variables.
+func testEnv(env map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestEnvSupportsColor(t *testing.T) {
+	tests := map[string]struct {
+		env      map[string]string
+		terminal bool
+		expected bool
+	}{
+		"Terminal":                  {terminal: true, expected: true},
+		"Not Terminal":              {terminal: false, expected: false},
+		"NO_COLOR":                  {env: map[string]string{"NO_COLOR": ""}, terminal: true, expected: false},
+		"FORCE_COLOR":               {env: map[string]string{"FORCE_COLOR": "1"}, terminal: false, expected: true},
+		"FORCE_COLOR Empty":         {env: map[string]string{"FORCE_COLOR": ""}, terminal: false, expected: true},
+		"FORCE_COLOR=0":             {env: map[string]string{"FORCE_COLOR": "0"}, terminal: true, expected: false},
+		"FORCE_COLOR=false":         {env: map[string]string{"FORCE_COLOR": "false"}, terminal: true, expected: false},
+		"FORCE_COLOR Over NO_COLOR": {env: map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, expected: true},
+		"CLICOLOR_FORCE":            {env: map[string]string{"CLICOLOR_FORCE": "1"}, terminal: false, expected: true},
+		"CLICOLOR_FORCE=0":          {env: map[string]string{"CLICOLOR_FORCE": "0"}, terminal: false, expected: false},
+		"CLICOLOR=0":                {env: map[string]string{"CLICOLOR": "0"}, terminal: true, expected: false},
+		"CLICOLOR=1":                {env: map[string]string{"CLICOLOR": "1"}, terminal: true, expected: true},
+		"TERM=dumb":                 {env: map[string]string{"TERM": "dumb"}, terminal: true, expected: false},
+		"TERM=dumb CLICOLOR_FORCE":  {env: map[string]string{"TERM": "dumb", "CLICOLOR_FORCE": "1"}, expected: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := envSupportsColor(testEnv(tc.env), tc.terminal)
+			if got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	resetGlobals(t)
+
+	errs := applyEnvironment(testEnv(map[string]string{
+		EnvVerbosity: "4",
+		EnvFormat:    "json",
+	}))
+
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	if GetVerbosity() != 4 {
+		t.Fatalf("expected verbosity 4, got %d", GetVerbosity())
+	}
+
+	if _, ok := GetPrinter().(*JSONPrinter); !ok {
+		t.Fatalf("expected global printer to be a JSONPrinter, got %T", GetPrinter())
+	}
+}
+
+func TestApplyEnvironmentInvalid(t *testing.T) {
+	resetGlobals(t)
+
+	errs := applyEnvironment(testEnv(map[string]string{
+		EnvVerbosity: "four",
+		EnvFormat:    "xml",
+	}))
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+}
+
+func TestEnvironmentPrecedence(t *testing.T) {
+	resetGlobals(t)
+
+	// Flags take precedence over environment variables.
+	applyEnvironment(testEnv(map[string]string{EnvVerbosity: "4", EnvFormat: "json"}))
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+	if err := fs.Parse([]string{"-v=3", "-format=text"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if GetVerbosity() != 3 {
+		t.Fatalf("expected flag verbosity 3, got %d", GetVerbosity())
+	}
+
+	if _, ok := GetPrinter().(*Printer); !ok {
+		t.Fatalf("expected flag format to override environment, got %T", GetPrinter())
+	}
+
+	// SetPrinter takes precedence over environment variables.
+	p := &testPrinter{}
+	SetPrinter(p)
+	applyEnvironment(testEnv(map[string]string{EnvFormat: "json"}))
+	if GetPrinter() != p {
+		t.Fatalf("expected environment to not replace a printer set with SetPrinter")
+	}
+}
@@ -0,0 +1,98 @@
+package clout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorHint is an optional interface for errors that provide a hint on how to resolve them.
+// The hint is printed below the error when printed with Verbose.Err.
+type ErrorHint interface {
+	Hint() string
+}
+
+// ErrorDetails is an optional interface for errors that provide additional details.
+// The details are printed below the error when printed with Verbose.Err.
+type ErrorDetails interface {
+	Details() string
+}
+
+// errorNode is a single layer of an error chain or tree.
+type errorNode struct {
+	text    string
+	hint    string
+	details string
+	causes  []errorNode
+}
+
+// newErrorNode creates an errorNode tree from an error.
+//
+// Errors that wrap a single error (with an Unwrap() error method) have the wrapped error's text removed from their
+// own text, so "read config: open file: not found" becomes "read config" -> "open file" -> "not found".
+// Errors that wrap multiple errors (like errors.Join) become branches in the tree.
+func newErrorNode(err error) errorNode {
+	node := errorNode{text: err.Error()}
+	if hint, ok := err.(ErrorHint); ok {
+		node.hint = hint.Hint()
+	}
+
+	if details, ok := err.(ErrorDetails); ok {
+		node.details = details.Details()
+	}
+
+	switch unwrapper := err.(type) {
+	case interface{ Unwrap() []error }:
+		var texts []string
+		for _, cause := range unwrapper.Unwrap() {
+			if cause != nil {
+				node.causes = append(node.causes, newErrorNode(cause))
+				texts = append(texts, cause.Error())
+			}
+		}
+
+		if node.text == strings.Join(texts, "\n") {
+			node.text = ""
+		}
+
+	case interface{ Unwrap() error }:
+		if cause := unwrapper.Unwrap(); cause != nil {
+			causeText := cause.Error()
+			node.text = strings.TrimSuffix(node.text, ": "+causeText)
+			if node.text == causeText {
+				node.text = ""
+			}
+
+			node.causes = []errorNode{newErrorNode(cause)}
+		}
+	}
+
+	return node
+}
+
+// collapse skips over layers of the error chain that have no text of their own.
+// Hints and details from the skipped layers are kept if the wrapped error doesn't have its own.
+func (n errorNode) collapse() errorNode {
+	for n.text == "" && len(n.causes) == 1 {
+		cause := n.causes[0]
+		if cause.hint == "" {
+			cause.hint = n.hint
+		}
+
+		if cause.details == "" {
+			cause.details = n.details
+		}
+
+		n = cause
+	}
+
+	return n
+}
+
+// summary returns the text used as the message of the error.
+func (n errorNode) summary() string {
+	if n.text == "" && len(n.causes) > 1 {
+		return fmt.Sprintf("%d errors occurred", len(n.causes))
+	}
+
+	return n.text
+}
@@ -0,0 +1,119 @@
+package clout
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.eth-p.dev/clout/pkg/color"
+)
+
+// testHintError is an error that implements ErrorHint and ErrorDetails.
+type testHintError struct {
+	err error
+}
+
+func (e testHintError) Error() string   { return e.err.Error() }
+func (e testHintError) Unwrap() error   { return e.err }
+func (e testHintError) Hint() string    { return "try again" }
+func (e testHintError) Details() string { return "line one\nline two" }
+
+// testJoinError is an error that wraps multiple errors, like errors.Join.
+type testJoinError []error
+
+func (e testJoinError) Error() string {
+	return e[0].Error() + "\n" + e[1].Error()
+}
+
+func (e testJoinError) Unwrap() []error {
+	return e
+}
+
+func TestVerboseErr(t *testing.T) {
+	base := errors.New("not found")
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"Simple": {
+			err:      base,
+			expected: "error: not found\n",
+		},
+		"Wrapped": {
+			err: fmt.Errorf("read config: %w", fmt.Errorf("open file: %w", base)),
+			expected: "error: read config\n" +
+				"  caused by: open file\n" +
+				"  caused by: not found\n",
+		},
+		"Wrapped Without Suffix": {
+			err: fmt.Errorf("something else (%w)", base),
+			expected: "error: something else (not found)\n" +
+				"  caused by: not found\n",
+		},
+		"Wrapped Without Text": {
+			err: testJoinError{
+				fmt.Errorf("open a: %w", base),
+				fmt.Errorf("open b: %w", fmt.Errorf("%w", base)),
+			},
+			expected: "error: 2 errors occurred\n" +
+				"  - open a\n" +
+				"      caused by: not found\n" +
+				"  - open b\n" +
+				"      caused by: not found\n",
+		},
+		"Hint And Details": {
+			err: fmt.Errorf("read config: %w", testHintError{fmt.Errorf("open file: %w", base)}),
+			expected: "error: read config\n" +
+				"  caused by: open file\n" +
+				"  hint: try again\n" +
+				"  line one\n" +
+				"  line two\n" +
+				"  caused by: not found\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			output := OutputFromWriter(buf).WithPrefix("error:", color.Plain())
+			printer := NewPrinter().SetOutput(output)
+
+			v := Verbose{printer: printer, enabled: true}
+			v.Err(tc.err)
+
+			got := buf.String()
+			if tc.expected != got {
+				t.Fatalf("expected:\n%s\ngot:\n%s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestVerboseErrNil(t *testing.T) {
+	p := &testPrinter{}
+	v := Verbose{printer: p, enabled: true}
+	v.Err(nil)
+
+	if len(p.messages) != 0 {
+		t.Fatalf("expected no messages for nil error, got %v", p.messages)
+	}
+}
+
+func TestVerboseErrColors(t *testing.T) {
+	buf := new(bytes.Buffer)
+	output := OutputFromWriter(buf).
+		WithColor(color.Foreground(color.Red)).
+		WithPrefix("error:", color.Foreground(color.Red).Bold(true)).
+		WithColors(true)
+
+	v := Verbose{printer: NewPrinter().SetOutput(output), enabled: true}
+	v.Err(fmt.Errorf("a: %w", errors.New("b")))
+
+	expected := "\x1B[1;31merror:\x1B[0m \x1B[31ma\x1B[0m\n" +
+		"  \x1B[1;31mcaused by:\x1B[0m \x1B[31mb\x1B[0m\n"
+
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected: %#v, got: %#v", expected, got)
+	}
+}
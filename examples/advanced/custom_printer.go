@@ -20,7 +20,14 @@ func (m MyPrinter) Print(message clout.Message) {
 	text := fitm.Sprintf(applyHighlights, message.Format(), message.FormatArgs()...)
 
 	// Print the formatted text.
-	fmt.Printf("[V(%d)] %s\n", message.Verbosity(), text)
+	// The time and caller are available because they were requested by the Capture method.
+	fmt.Printf("%s %s [V(%d)] %s\n", message.Time().Format("15:04:05"), message.Caller(), message.Verbosity(), text)
+}
+
+// Capture is called to find out what optional information should be captured when a message is created.
+// Capturing information has a small cost, so it is only done for printers that ask for it.
+func (m MyPrinter) Capture() clout.Capture {
+	return clout.CaptureTime | clout.CaptureCaller
 }
 
 // applyHighlights is a fitm.FormatMitm function which applies highlight.Highlight wrappers to format arguments.
@@ -0,0 +1,131 @@
+package clout
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+var exitMutex sync.Mutex
+var exitHooks []func()
+var exitFunc = os.Exit
+var exitRunning bool
+var fatalExitCode = 1
+
+// brokenPipe is set to 1 when a printer with the ExitOnBrokenPipe policy fails to write to a closed pipe.
+var brokenPipe int32
+
+var sigpipeOnce sync.Once
+
+// RegisterExitHook registers a function that will be called before the program exits through Exit.
+// Hooks are called in the reverse order that they were registered in, before any printers are flushed.
+func RegisterExitHook(hook func()) {
+	exitMutex.Lock()
+	exitHooks = append(exitHooks, hook)
+	exitMutex.Unlock()
+}
+
+// SetExitFunc replaces the function used by Exit to terminate the program.
+// This is intended to be used in tests. If the function is nil, os.Exit will be used.
+func SetExitFunc(fn func(code int)) {
+	if fn == nil {
+		fn = os.Exit
+	}
+
+	exitMutex.Lock()
+	exitFunc = fn
+	exitMutex.Unlock()
+}
+
+// SetFatalExitCode sets the exit code used by Verbose.Fatalf.
+// The default exit code is 1.
+func SetFatalExitCode(code int) {
+	exitMutex.Lock()
+	fatalExitCode = code
+	exitMutex.Unlock()
+}
+
+// Exit runs the registered exit hooks, flushes the global PrinterInterface, and exits the program.
+//
+// If Exit is called from inside an exit hook, the remaining hooks will be skipped.
+func Exit(code int) {
+	exitPrinter(nil, code)
+}
+
+// exitPrinter runs the registered exit hooks, flushes a PrinterInterface and the global PrinterInterface,
+// and exits the program. If the PrinterInterface is nil, only the global PrinterInterface is flushed.
+func exitPrinter(printer PrinterInterface, code int) {
+	exitMutex.Lock()
+	hooks := exitHooks
+	if exitRunning {
+		hooks = nil
+	}
+
+	exitRunning = true
+	exit := exitFunc
+	exitMutex.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+
+	// The printers aren't compared, since that panics for printers with a non-comparable type.
+	// Flushing the same printer twice is harmless.
+	ctx := context.Background()
+	_ = FlushPrinter(ctx, printer)
+	_ = FlushPrinter(ctx, GetPrinter())
+
+	exitMutex.Lock()
+	exitRunning = false
+	exitMutex.Unlock()
+
+	exit(code)
+}
+
+// setBrokenPipe records that a printer failed to write to a closed pipe.
+//
+// The program can't exit from inside the printer, since Exit would deadlock flushing printers that are still
+// printing the message (e.g. an AsyncPrinter or DedupPrinter). Instead, the program exits with exitOnBrokenPipe
+// once the message has finished printing.
+func setBrokenPipe() {
+	atomic.StoreInt32(&brokenPipe, 1)
+}
+
+// exitOnBrokenPipe exits the program with code 0 through Exit if a printer failed to write to a closed pipe.
+// The exit hooks are run and the printers are flushed before this returns, so the caller can't keep running.
+//
+// This must be called after a message has finished printing, while no printers are in the middle of printing.
+// Broken pipes that happen while the program is already exiting are ignored.
+func exitOnBrokenPipe() {
+	if atomic.LoadInt32(&brokenPipe) == 0 {
+		return
+	}
+
+	exitMutex.Lock()
+	running := exitRunning
+	exitMutex.Unlock()
+
+	if !running && atomic.CompareAndSwapInt32(&brokenPipe, 1, 0) {
+		Exit(0)
+	}
+}
+
+// handleSIGPIPE stops the Go runtime from killing the program with SIGPIPE when writing to stdout or stderr fails
+// because the pipe was closed. The write returns an EPIPE error instead, which is handled by the WriteErrorPolicy.
+//
+// The signal is handled with signal.Notify instead of signal.Ignore so that child processes still receive it.
+func handleSIGPIPE() {
+	sigpipeOnce.Do(func() {
+		signal.Notify(make(chan os.Signal, 1), syscall.SIGPIPE)
+	})
+}
+
+// getFatalExitCode gets the exit code used by Verbose.Fatalf.
+func getFatalExitCode() int {
+	exitMutex.Lock()
+	defer exitMutex.Unlock()
+	return fatalExitCode
+}
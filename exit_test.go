@@ -0,0 +1,159 @@
+package clout
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// captureExit replaces the exit function for a test, returning a pointer to the last exit code.
+func captureExit(t *testing.T) *int {
+	code := -1
+	SetExitFunc(func(c int) { code = c })
+	t.Cleanup(func() {
+		SetExitFunc(nil)
+		SetFatalExitCode(1)
+
+		exitMutex.Lock()
+		exitHooks = nil
+		exitMutex.Unlock()
+
+		atomic.StoreInt32(&brokenPipe, 0)
+	})
+
+	return &code
+}
+
+func TestExit(t *testing.T) {
+	resetGlobals(t)
+	code := captureExit(t)
+
+	p := &testFlushPrinter{}
+	SetPrinter(p)
+
+	var order []string
+	RegisterExitHook(func() { order = append(order, "first") })
+	RegisterExitHook(func() { order = append(order, "second") })
+	RegisterExitHook(func() {
+		if p.flushed != 0 {
+			t.Errorf("expected exit hooks to be called before flushing")
+		}
+	})
+
+	Exit(3)
+
+	if *code != 3 {
+		t.Fatalf("expected exit code 3, got %d", *code)
+	}
+
+	if p.flushed != 1 {
+		t.Fatalf("expected printer to be flushed once, got %d", p.flushed)
+	}
+
+	if diff := cmp.Diff([]string{"second", "first"}, order); diff != "" {
+		t.Log("exit hooks not called in expected order; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
+
+func TestExitFromHook(t *testing.T) {
+	resetGlobals(t)
+	code := captureExit(t)
+
+	calls := 0
+	RegisterExitHook(func() {
+		calls++
+		Exit(4)
+	})
+
+	Exit(3)
+	if calls != 1 {
+		t.Fatalf("expected exit hook to be called once, got %d", calls)
+	}
+
+	if *code != 3 {
+		t.Fatalf("expected exit code 3, got %d", *code)
+	}
+}
+
+// testValuePrinter is a printer with a type that can't be compared with ==.
+type testValuePrinter struct {
+	messages *[]Message
+	flushed  []int
+}
+
+func (p testValuePrinter) Print(message Message) {
+	*p.messages = append(*p.messages, message)
+}
+
+func (p testValuePrinter) Flush(ctx context.Context) error {
+	return nil
+}
+
+func TestExitValuePrinter(t *testing.T) {
+	resetGlobals(t)
+	code := captureExit(t)
+
+	var messages []Message
+	SetPrinter(testValuePrinter{messages: &messages})
+
+	V(0).Fatalf("boom")
+	if *code != 1 || len(messages) != 1 {
+		t.Fatalf("expected Fatalf to print and exit, got code %d and %d messages", *code, len(messages))
+	}
+}
+
+func TestVerboseFatal(t *testing.T) {
+	tests := map[string]struct {
+		fn       func(v *Verbose)
+		expected int
+	}{
+		"Fatalf": {
+			fn:       func(v *Verbose) { v.Fatalf("hello %s", "fatal") },
+			expected: 1,
+		},
+		"Fatalf With Code": {
+			fn: func(v *Verbose) {
+				SetFatalExitCode(7)
+				v.Fatalf("hello %s", "fatal")
+			},
+			expected: 7,
+		},
+		"Fatal": {
+			fn:       func(v *Verbose) { v.Fatal("hello", "fatal") },
+			expected: 1,
+		},
+		"Exitf": {
+			fn:       func(v *Verbose) { v.Exitf(5, "hello %s", "fatal") },
+			expected: 5,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetGlobals(t)
+			code := captureExit(t)
+
+			p := &testFlushPrinter{}
+			tc.fn(&Verbose{printer: p, enabled: false})
+
+			if *code != tc.expected {
+				t.Fatalf("expected exit code %d, got %d", tc.expected, *code)
+			}
+
+			if len(p.messages) != 1 || p.messages[0].Kind() != Error {
+				t.Fatalf("expected one Error message, got %v", p.messages)
+			}
+
+			if p.messages[0].String() != "hello fatal" {
+				t.Fatalf("expected message \"hello fatal\", got %q", p.messages[0].String())
+			}
+
+			if p.flushed != 1 {
+				t.Fatalf("expected printer to be flushed once, got %d", p.flushed)
+			}
+		})
+	}
+}
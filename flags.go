@@ -0,0 +1,209 @@
+package clout
+
+import (
+	"flag"
+	"strconv"
+)
+
+// FlagValue is a flag.Value that can also be used with github.com/spf13/pflag.
+//
+// When used with pflag, boolean flags (QuietFlag and LogToStderrFlag) should have their NoOptDefVal set to "true".
+type FlagValue interface {
+	flag.Value
+
+	// Type returns the name of the value type.
+	Type() string
+}
+
+// RegisterFlags registers command-line flags for configuring clout in a flag.FlagSet.
+// If the flag.FlagSet is nil, flag.CommandLine will be used.
+//
+// The following flags are registered:
+//
+//   -v            The verbosity level (see SetVerbosity).
+//   -vmodule      Per-file and per-package verbosity overrides (see SetVModule).
+//   -quiet        Only print warnings and errors.
+//   -color        When to print colors: "auto", "always", or "never".
+//   -format       The output format: "text" or "json".
+//   -logtostderr  Print all messages to stderr.
+//
+// The flag names for -v, -vmodule, and -logtostderr are the same as klog's flags.
+// When the flags are parsed, the global verbosity and default global printer will be updated.
+func RegisterFlags(fs *flag.FlagSet) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+
+	fs.Var(VerbosityFlag(), "v", "number for the log level verbosity")
+	fs.Var(VModuleFlag(), "vmodule", "comma-separated list of pattern=N settings for file-filtered logging")
+	fs.Var(QuietFlag(), "quiet", "only print warnings and errors")
+	fs.Var(ColorFlag(), "color", "when to print colors: auto, always, or never")
+	fs.Var(FormatFlag(), "format", "output format: text or json")
+	fs.Var(LogToStderrFlag(), "logtostderr", "print all messages to stderr")
+}
+
+// VerbosityFlag creates a FlagValue that sets the global verbosity.
+func VerbosityFlag() FlagValue {
+	return verbosityFlag{}
+}
+
+// VModuleFlag creates a FlagValue that sets the vmodule specification.
+func VModuleFlag() FlagValue {
+	return vmoduleFlag{}
+}
+
+// ColorFlag creates a FlagValue that sets the ColorMode of the default printer.
+func ColorFlag() FlagValue {
+	return colorFlag{}
+}
+
+// FormatFlag creates a FlagValue that sets the OutputFormat of the default printer.
+func FormatFlag() FlagValue {
+	return formatFlag{}
+}
+
+// QuietFlag creates a boolean FlagValue that makes the default printer discard messages below WarningSeverity.
+func QuietFlag() FlagValue {
+	return quietFlag{}
+}
+
+// LogToStderrFlag creates a boolean FlagValue that makes the default printer print all messages to stderr.
+func LogToStderrFlag() FlagValue {
+	return logToStderrFlag{}
+}
+
+// verbosityFlag is a FlagValue for the global verbosity.
+type verbosityFlag struct{}
+
+func (verbosityFlag) String() string {
+	return strconv.Itoa(int(GetVerbosity()))
+}
+
+func (verbosityFlag) Set(value string) error {
+	verbosity, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+
+	SetVerbosity(MessageVerbosity(verbosity))
+	return nil
+}
+
+func (verbosityFlag) Type() string {
+	return "level"
+}
+
+// vmoduleFlag is a FlagValue for the vmodule specification.
+type vmoduleFlag struct{}
+
+func (vmoduleFlag) String() string {
+	return GetVModule()
+}
+
+func (vmoduleFlag) Set(value string) error {
+	return SetVModule(value)
+}
+
+func (vmoduleFlag) Type() string {
+	return "pattern=N,..."
+}
+
+// colorFlag is a FlagValue for the ColorMode of the default printer.
+type colorFlag struct{}
+
+func (colorFlag) String() string {
+	globalSettingsMutex.Lock()
+	defer globalSettingsMutex.Unlock()
+	return globalSettings.color.String()
+}
+
+func (colorFlag) Set(value string) error {
+	mode, err := ParseColorMode(value)
+	if err != nil {
+		return err
+	}
+
+	updateSettings(func(s *settings) { s.color = mode })
+	return nil
+}
+
+func (colorFlag) Type() string {
+	return "mode"
+}
+
+// formatFlag is a FlagValue for the OutputFormat of the default printer.
+type formatFlag struct{}
+
+func (formatFlag) String() string {
+	globalSettingsMutex.Lock()
+	defer globalSettingsMutex.Unlock()
+	return globalSettings.format.String()
+}
+
+func (formatFlag) Set(value string) error {
+	format, err := ParseOutputFormat(value)
+	if err != nil {
+		return err
+	}
+
+	updateSettings(func(s *settings) { s.format = format })
+	return nil
+}
+
+func (formatFlag) Type() string {
+	return "format"
+}
+
+// quietFlag is a boolean FlagValue for discarding messages below WarningSeverity.
+type quietFlag struct{}
+
+func (quietFlag) String() string {
+	globalSettingsMutex.Lock()
+	defer globalSettingsMutex.Unlock()
+	return strconv.FormatBool(globalSettings.quiet)
+}
+
+func (quietFlag) Set(value string) error {
+	quiet, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+
+	updateSettings(func(s *settings) { s.quiet = quiet })
+	return nil
+}
+
+func (quietFlag) Type() string {
+	return "bool"
+}
+
+func (quietFlag) IsBoolFlag() bool {
+	return true
+}
+
+// logToStderrFlag is a boolean FlagValue for printing all messages to stderr.
+type logToStderrFlag struct{}
+
+func (logToStderrFlag) String() string {
+	globalSettingsMutex.Lock()
+	defer globalSettingsMutex.Unlock()
+	return strconv.FormatBool(globalSettings.toStderr)
+}
+
+func (logToStderrFlag) Set(value string) error {
+	toStderr, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+
+	updateSettings(func(s *settings) { s.toStderr = toStderr })
+	return nil
+}
+
+func (logToStderrFlag) Type() string {
+	return "bool"
+}
+
+func (logToStderrFlag) IsBoolFlag() bool {
+	return true
+}
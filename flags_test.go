@@ -0,0 +1,129 @@
+package clout
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// resetGlobals restores the global verbosity, vmodule, and printer settings after a test.
+func resetGlobals(t *testing.T) {
+	verbosity := GetVerbosity()
+	t.Cleanup(func() {
+		globalSettingsMutex.Lock()
+		globalSettings = settings{}
+		globalSettingsMutex.Unlock()
+
+		ResetPrinter()
+		SetVerbosity(verbosity)
+		_ = SetVModule("")
+	})
+}
+
+func TestRegisterFlags(t *testing.T) {
+	resetGlobals(t)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	RegisterFlags(fs)
+
+	err := fs.Parse([]string{"-v=4", "-vmodule=cache*=5", "-color=never", "-format=json", "-quiet", "-logtostderr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if GetVerbosity() != 4 {
+		t.Fatalf("expected verbosity 4, got %d", GetVerbosity())
+	}
+
+	if GetVModule() != "cache*=5" {
+		t.Fatalf("expected vmodule \"cache*=5\", got %q", GetVModule())
+	}
+
+	expected := settings{color: ColorNever, format: JSONFormat, quiet: true, toStderr: true}
+	if globalSettings != expected {
+		t.Fatalf("expected settings %+v, got %+v", expected, globalSettings)
+	}
+
+	quiet, ok := GetPrinter().(quietPrinter)
+	if !ok {
+		t.Fatalf("expected global printer to be a quietPrinter, got %T", GetPrinter())
+	}
+
+	if _, ok := quiet.printer.(*JSONPrinter); !ok {
+		t.Fatalf("expected global printer to wrap a JSONPrinter, got %T", quiet.printer)
+	}
+}
+
+func TestRegisterFlagsInvalid(t *testing.T) {
+	resetGlobals(t)
+
+	tests := map[string][]string{
+		"Verbosity": {"-v=x"},
+		"VModule":   {"-vmodule=x"},
+		"Color":     {"-color=sometimes"},
+		"Format":    {"-format=xml"},
+		"Quiet":     {"-quiet=maybe"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			RegisterFlags(fs)
+
+			if err := fs.Parse(args); err == nil {
+				t.Fatalf("expected error for %v", args)
+			}
+		})
+	}
+}
+
+func TestRegisterFlagsWithCustomPrinter(t *testing.T) {
+	resetGlobals(t)
+
+	p := &testPrinter{}
+	SetPrinter(p)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+	if err := fs.Parse([]string{"-color=never", "-format=json"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if GetPrinter() != p {
+		t.Fatalf("expected flags to not replace a printer set with SetPrinter")
+	}
+
+	ResetPrinter()
+	if _, ok := GetPrinter().(*JSONPrinter); !ok {
+		t.Fatalf("expected ResetPrinter to use flag settings, got %T", GetPrinter())
+	}
+}
+
+func TestQuietPrinter(t *testing.T) {
+	resetGlobals(t)
+	resetKinds(t)
+
+	custom := RegisterKind("quiet-test", InfoSeverity, KindOutput{})
+	important := RegisterKind("quiet-test-important", ErrorSeverity, KindOutput{})
+
+	p := &testPrinter{}
+	quiet := quietPrinter{p}
+	for _, kind := range []MessageKind{Status, Info, Success, Debug, Trace, Hint, custom, Warning, Deprecation, Error, important} {
+		quiet.Print(New(kind, 0, "%s", kind))
+	}
+
+	var got []string
+	for _, message := range p.messages {
+		got = append(got, message.String())
+	}
+
+	diff := cmp.Diff([]string{"warning", "deprecation", "error", "quiet-test-important"}, got)
+	if diff != "" {
+		t.Log("did not find expected messages; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,29 @@
+package clout
+
+import (
+	"context"
+)
+
+// Flusher is an optional interface for PrinterInterface implementations that buffer messages.
+type Flusher interface {
+
+	// Flush writes any buffered messages.
+	// This should block until the messages are written or the context is done.
+	Flush(ctx context.Context) error
+}
+
+// Flush flushes any buffered messages in the global PrinterInterface.
+// If the global PrinterInterface does not implement Flusher, this does nothing.
+func Flush() error {
+	return FlushPrinter(context.Background(), GetPrinter())
+}
+
+// FlushPrinter flushes any buffered messages in a PrinterInterface.
+// If the PrinterInterface does not implement Flusher, this does nothing.
+func FlushPrinter(ctx context.Context, printer PrinterInterface) error {
+	if flusher, ok := printer.(Flusher); ok {
+		return flusher.Flush(ctx)
+	}
+
+	return nil
+}
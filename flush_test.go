@@ -0,0 +1,32 @@
+package clout
+
+import (
+	"context"
+	"testing"
+)
+
+type testFlushPrinter struct {
+	testPrinter
+	flushed int
+}
+
+func (p *testFlushPrinter) Flush(ctx context.Context) error {
+	p.flushed++
+	return nil
+}
+
+func TestFlushPrinter(t *testing.T) {
+	p := &testFlushPrinter{}
+	if err := FlushPrinter(context.Background(), p); err != nil {
+		t.Fatal(err)
+	}
+
+	if p.flushed != 1 {
+		t.Fatalf("expected printer to be flushed once, got %d", p.flushed)
+	}
+
+	// A printer that doesn't implement Flusher should be ignored.
+	if err := FlushPrinter(context.Background(), &testPrinter{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,7 +2,10 @@ package clout
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"go.eth-p.dev/clout/pkg/color"
 	"go.eth-p.dev/clout/pkg/fitm"
 	"go.eth-p.dev/clout/pkg/highlight"
 )
@@ -21,6 +24,112 @@ func formatText(message *Message, colors bool) string {
 	return fitm.Sprintf(mitmFunc, message.Format(), message.FormatArgs()...)
 }
 
+// nameStyle is the color.Style applied to Message names when colors are enabled.
+var nameStyle = color.Plain().Bold(true)
+
+// formatName formats a Message's name into a string that can be printed before the text.
+// Colored text will be enabled or disabled based on the value of the colors parameter.
+func formatName(message *Message, colors bool) string {
+	name := message.Name() + ":"
+	if colors {
+		name = nameStyle.Apply(name)
+	}
+
+	return name
+}
+
+// errorIndent is the indentation used for each level of an error tree.
+const errorIndent = "  "
+
+// formatErrorLines formats the hints, details, and causes of a Message's error into lines of text.
+// The labels (e.g. "caused by:") are styled with labelStyle, and the text is styled with textStyle.
+func formatErrorLines(message *Message, colors bool, labelStyle color.Style, textStyle color.Style) []string {
+	if message.Err() == nil {
+		return nil
+	}
+
+	if !colors {
+		labelStyle = color.Plain()
+		textStyle = color.Plain()
+	}
+
+	node := newErrorNode(message.Err()).collapse()
+	return appendErrorLines(nil, node, errorIndent, labelStyle, textStyle)
+}
+
+// appendErrorLines appends the hints, details, and causes of an errorNode to a slice of lines.
+func appendErrorLines(lines []string, node errorNode, indent string, labelStyle color.Style, textStyle color.Style) []string {
+	if node.hint != "" {
+		lines = append(lines, indent+labelStyle.Apply("hint:")+" "+textStyle.Apply(node.hint))
+	}
+
+	if node.details != "" {
+		for _, line := range strings.Split(node.details, "\n") {
+			lines = append(lines, indent+textStyle.Apply(line))
+		}
+	}
+
+	switch len(node.causes) {
+	case 0:
+		return lines
+
+	case 1:
+		cause := node.causes[0].collapse()
+		lines = append(lines, indent+labelStyle.Apply("caused by:")+" "+textStyle.Apply(cause.summary()))
+		return appendErrorLines(lines, cause, indent, labelStyle, textStyle)
+
+	default:
+		for _, cause := range node.causes {
+			cause = cause.collapse()
+			lines = append(lines, indent+labelStyle.Apply("-")+" "+textStyle.Apply(cause.summary()))
+			lines = appendErrorLines(lines, cause, indent+errorIndent+errorIndent, labelStyle, textStyle)
+		}
+
+		return lines
+	}
+}
+
+// fieldKeyStyle is the color.Style applied to Field keys when colors are enabled.
+var fieldKeyStyle = color.Foreground(color.Cyan)
+
+// formatFields formats a Message's fields into a string of space-prefixed "key=value" pairs.
+// Colored text will be enabled or disabled based on the value of the colors parameter.
+func formatFields(message *Message, colors bool) string {
+	var sb strings.Builder
+	for _, field := range message.Fields() {
+		key := field.Key
+		value := field.Value
+		highlighter, highlighted := value.(highlight.Highlight)
+		if highlighted {
+			value = highlighter.Value()
+		}
+
+		text := quoteFieldValue(fmt.Sprintf("%v", value))
+		if colors {
+			key = fieldKeyStyle.Apply(key)
+			if highlighted {
+				text = highlighter.Apply(text)
+			}
+		}
+
+		sb.WriteString(" ")
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(text)
+	}
+
+	return sb.String()
+}
+
+// quoteFieldValue quotes a formatted Field value if it would be ambiguous when printed as "key=value".
+func quoteFieldValue(text string) string {
+	if text == "" || strings.ContainsAny(text, " \t\r\n\"=") {
+		return strconv.Quote(text)
+	}
+
+	return text
+}
+
 // fitmApplyColors is a fitm.FormatMitm that applies colors from highlight.Highlight objects.
 func fitmApplyColors(verb fitm.Verb, val interface{}) (fitm.Verb, interface{}) {
 	if highlighter, ok := val.(highlight.Highlight); ok {
@@ -1,6 +1,7 @@
 package clout
 
 import (
+	"context"
 	"sync"
 )
 
@@ -17,7 +18,28 @@ func GetPrinter() PrinterInterface {
 }
 
 // SetPrinter sets the global PrinterInterface instance.
+// The previous PrinterInterface is flushed first, so buffered messages are printed before any new ones.
+//
+// Once a printer is set, options from RegisterFlags will no longer replace the global PrinterInterface.
+// Use ResetPrinter to go back to the default printer.
 func SetPrinter(processor PrinterInterface) {
+	_ = FlushPrinter(context.Background(), GetPrinter())
+
+	globalSettingsMutex.Lock()
+	globalPrinterIsDefault = false
+	setPrinter(processor)
+	globalSettingsMutex.Unlock()
+}
+
+// ResetPrinter sets the global PrinterInterface instance back to the default printer.
+// The previous PrinterInterface is flushed first, so buffered messages are printed before any new ones.
+func ResetPrinter() {
+	_ = FlushPrinter(context.Background(), GetPrinter())
+	resetDefaultPrinter()
+}
+
+// setPrinter sets the global PrinterInterface instance.
+func setPrinter(processor PrinterInterface) {
 	globalPrinterMutex.Lock()
 	globalPrinter = processor
 	globalPrinterMutex.Unlock()
@@ -44,14 +66,19 @@ func SetVerbosity(verbosity MessageVerbosity) {
 //
 //     V(2).Warningf("unknown path: %v", highlight.Cyan("/not-a-path"))
 func V(verbosity MessageVerbosity) *Verbose {
-	return &Verbose{
-		enabled:   verbosity <= GetVerbosity(),
-		verbosity: verbosity,
-		printer:   GetPrinter(),
-	}
+	return VDepth(1, verbosity)
+}
+
+// VDepth creates a struct to print messages, using the caller depth for vmodule overrides.
+// A depth of 0 is the caller of VDepth, and a depth of 1 is the caller's caller.
+//
+// This is intended for wrappers around clout that need vmodule patterns to match against their own callers.
+func VDepth(depth int, verbosity MessageVerbosity) *Verbose {
+	return Logger{}.VDepth(depth+1, verbosity)
 }
 
 func init() {
-	SetPrinter(NewPrinterWithDefaults(true))
+	resetDefaultPrinter()
 	SetVerbosity(defaultVerbosity)
+	applyDefaultEnvironment()
 }
@@ -0,0 +1,253 @@
+package clout
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// MessageGroup collects the messages of a concurrent task so that they are printed as one contiguous block.
+//
+// Messages printed through a MessageGroup are buffered until the group ends, then printed after a header with the
+// group's name. This keeps the output of tasks running in parallel from being interleaved.
+//
+// When live groups are enabled (see SetLiveGroups), the oldest group is "focused" and its messages are printed as
+// soon as they are created. When the focused group ends, the next oldest group prints its buffered messages and
+// becomes focused.
+//
+// Example:
+//
+//     group := clout.Group("build")
+//     defer group.End()
+//
+//     group.V(2).Statusf("compiling")
+//     cmd.Stdout = group.V(2).AsWriter(clout.Info)
+type MessageGroup struct {
+	name     string
+	printer  PrinterInterface
+	messages []Message
+	focused  bool
+	ended    bool
+	removed  bool
+}
+
+// groupMutex protects the state of the groups and the groupOutput queue.
+// Messages are never printed while holding it, so a slow printer does not block other groups from buffering.
+var groupMutex sync.Mutex
+var groupsActive []*MessageGroup
+var groupsLive *bool
+var groupOutput []groupedMessage
+
+// groupPrintMutex is held while printing the groupOutput queue, so that the queued messages are printed in order.
+var groupPrintMutex sync.Mutex
+
+// groupedMessage is a Message from a MessageGroup that is waiting to be printed.
+type groupedMessage struct {
+	printer PrinterInterface
+	message Message
+}
+
+// Group creates a MessageGroup that prints to the global PrinterInterface.
+// The group must be ended with End for its messages to be printed.
+func Group(name string) *MessageGroup {
+	group := &MessageGroup{
+		name:    name,
+		printer: GetPrinter(),
+	}
+
+	groupMutex.Lock()
+	groupsActive = append(groupsActive, group)
+	focused := liveGroupsEnabled() && len(groupsActive) == 1
+	if focused {
+		group.focus()
+	}
+	groupMutex.Unlock()
+
+	if focused {
+		printGroupOutput()
+		exitOnBrokenPipe()
+	}
+
+	return group
+}
+
+// SetLiveGroups enables or disables printing the messages of the focused MessageGroup as soon as they are created.
+// By default, live groups are enabled if stdout is a terminal.
+func SetLiveGroups(enabled bool) {
+	groupMutex.Lock()
+	groupsLive = &enabled
+	groupMutex.Unlock()
+}
+
+// Name returns the name of the MessageGroup.
+func (g *MessageGroup) Name() string {
+	return g.name
+}
+
+// V creates a struct to print messages in the MessageGroup.
+func (g *MessageGroup) V(verbosity MessageVerbosity) *Verbose {
+	return Logger{}.WithPrinter(groupPrinter{group: g}).VDepth(1, verbosity)
+}
+
+// End prints the buffered messages of the MessageGroup as one block.
+// Messages printed through the MessageGroup after it has ended are printed immediately.
+//
+// When live groups are enabled and another group is focused, the block is printed after the focused group ends.
+func (g *MessageGroup) End() {
+	groupMutex.Lock()
+	g.end()
+	groupMutex.Unlock()
+
+	printGroupOutput()
+	exitOnBrokenPipe()
+}
+
+// end ends the MessageGroup and queues its messages to be printed.
+// This must be called while holding the groupMutex.
+func (g *MessageGroup) end() {
+	if g.ended {
+		return
+	}
+
+	g.ended = true
+	if !g.focused && liveGroupsEnabled() && len(groupsActive) > 0 && groupsActive[0].focused {
+		return // Wait for the focused group to end.
+	}
+
+	if !g.focused {
+		g.queueBlock()
+	}
+
+	// Remove the group.
+	for i, active := range groupsActive {
+		if active == g {
+			groupsActive = append(groupsActive[:i], groupsActive[i+1:]...)
+			break
+		}
+	}
+
+	// Print the groups that ended while waiting, then focus the next one.
+	if g.focused {
+		for len(groupsActive) > 0 && groupsActive[0].ended {
+			groupsActive[0].queueBlock()
+			groupsActive[0].removed = true
+			groupsActive = groupsActive[1:]
+		}
+
+		if len(groupsActive) > 0 {
+			groupsActive[0].focus()
+		}
+	}
+
+	g.removed = true
+}
+
+// add prints or buffers a Message from the MessageGroup.
+func (g *MessageGroup) add(message Message) {
+	groupMutex.Lock()
+	if !g.focused && !g.removed {
+		g.messages = append(g.messages, message)
+		groupMutex.Unlock()
+		return
+	}
+
+	g.queue(message)
+	groupMutex.Unlock()
+
+	printGroupOutput()
+}
+
+// focus queues the buffered messages of the MessageGroup, and starts printing new messages immediately.
+// This must be called while holding the groupMutex.
+func (g *MessageGroup) focus() {
+	g.focused = true
+	g.queueHeader()
+	g.queue(g.messages...)
+	g.messages = nil
+}
+
+// queueBlock queues the header and buffered messages of the MessageGroup to be printed.
+// If there are no buffered messages, nothing is queued.
+// This must be called while holding the groupMutex.
+func (g *MessageGroup) queueBlock() {
+	if len(g.messages) == 0 {
+		return
+	}
+
+	g.queueHeader()
+	g.queue(g.messages...)
+	g.messages = nil
+}
+
+// queueHeader queues the header of the MessageGroup to be printed.
+// This must be called while holding the groupMutex.
+func (g *MessageGroup) queueHeader() {
+	g.queue(New(Status, 0, "==> %s", g.name))
+}
+
+// queue queues messages from the MessageGroup to be printed by printGroupOutput.
+// This must be called while holding the groupMutex.
+func (g *MessageGroup) queue(messages ...Message) {
+	for _, message := range messages {
+		groupOutput = append(groupOutput, groupedMessage{printer: g.printer, message: message})
+	}
+}
+
+// printGroupOutput prints the queued messages from all groups, in the order that they were queued.
+// This must not be called while holding the groupMutex.
+func printGroupOutput() {
+	groupPrintMutex.Lock()
+	defer groupPrintMutex.Unlock()
+
+	for {
+		groupMutex.Lock()
+		pending := groupOutput
+		groupOutput = nil
+		groupMutex.Unlock()
+
+		if len(pending) == 0 {
+			return
+		}
+
+		for _, grouped := range pending {
+			grouped.printer.Print(grouped.message)
+		}
+	}
+}
+
+// liveGroupsEnabled checks if live groups are enabled.
+// This must be called while holding the groupMutex.
+func liveGroupsEnabled() bool {
+	if groupsLive == nil {
+		live := isTerminal(os.Stdout)
+		groupsLive = &live
+	}
+
+	return *groupsLive
+}
+
+// groupPrinter is a PrinterInterface that adds messages to a MessageGroup.
+type groupPrinter struct {
+	group *MessageGroup
+}
+
+func (p groupPrinter) Print(message Message) {
+	p.group.add(message)
+}
+
+// Capture returns the information that the MessageGroup's PrinterInterface needs captured.
+func (p groupPrinter) Capture() Capture {
+	return captureOf(p.group.printer)
+}
+
+// MaxVerbosity returns the highest MessageVerbosity that the MessageGroup's PrinterInterface wants to receive.
+func (p groupPrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.group.printer)
+}
+
+// Flush ends the MessageGroup so that its messages are printed, then flushes the MessageGroup's PrinterInterface.
+// This ensures that a message printed with Verbose.Fatalf inside a group is not lost.
+func (p groupPrinter) Flush(ctx context.Context) error {
+	p.group.End()
+	return FlushPrinter(ctx, p.group.printer)
+}
@@ -0,0 +1,170 @@
+package clout
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func resetGroups(t *testing.T, live bool) {
+	SetLiveGroups(live)
+	t.Cleanup(func() {
+		groupMutex.Lock()
+		groupsActive = nil
+		groupsLive = nil
+		groupMutex.Unlock()
+	})
+}
+
+func TestGroup(t *testing.T) {
+	tests := map[string]struct {
+		expected []string
+		live     bool
+		fn       func()
+	}{
+		"Buffered": {
+			expected: []string{"==> b", "b1", "b2", "==> a", "a1", "a2"},
+			fn: func() {
+				a := Group("a")
+				b := Group("b")
+				a.V(0).Infof("a1")
+				b.V(0).Infof("b1")
+				a.V(0).Infof("a2")
+				b.V(0).Infof("b2")
+				b.End()
+				a.End()
+			},
+		},
+		"Empty": {
+			expected: nil,
+			fn: func() {
+				Group("a").End()
+			},
+		},
+		"After End": {
+			expected: []string{"==> a", "a1", "a2"},
+			fn: func() {
+				a := Group("a")
+				a.V(0).Infof("a1")
+				a.End()
+				a.End()
+				a.V(0).Infof("a2")
+			},
+		},
+		"AsWriter": {
+			expected: []string{"==> a", "line 1", "line 2"},
+			fn: func() {
+				a := Group("a")
+				_, _ = fmt.Fprint(a.V(0).AsWriter(Info), "line 1\nline 2\n")
+				a.End()
+			},
+		},
+		"Live": {
+			expected: []string{"==> a", "a1", "a2", "==> b", "b1", "b2", "==> c", "c1"},
+			live:     true,
+			fn: func() {
+				a := Group("a")
+				b := Group("b")
+				c := Group("c")
+				a.V(0).Infof("a1")
+				b.V(0).Infof("b1")
+				c.V(0).Infof("c1")
+				a.V(0).Infof("a2")
+				c.End()
+				a.End()
+				b.V(0).Infof("b2")
+				b.End()
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetGlobals(t)
+			resetGroups(t, tc.live)
+
+			p := &testPrinter{}
+			SetPrinter(p)
+
+			tc.fn()
+
+			var got []string
+			for _, message := range p.messages {
+				got = append(got, message.String())
+			}
+
+			diff := cmp.Diff(tc.expected, got)
+			if diff != "" {
+				t.Log("did not find expected messages; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestGroupFatal(t *testing.T) {
+	resetGlobals(t)
+	resetGroups(t, false)
+	code := captureExit(t)
+
+	p := &testPrinter{}
+	SetPrinter(p)
+
+	Group("a").V(0).Fatalf("oops")
+
+	if *code != 1 || len(p.messages) != 2 {
+		t.Fatalf("expected group to be printed before exiting, got code %d and %d messages", *code, len(p.messages))
+	}
+}
+
+func TestGroupSlowPrinter(t *testing.T) {
+	resetGlobals(t)
+	resetGroups(t, false)
+
+	slow := newTestBlockingPrinter()
+	SetPrinter(slow)
+	a := Group("a")
+	a.V(0).Infof("a1")
+
+	ended := make(chan struct{})
+	go func() {
+		a.End()
+		close(ended)
+	}()
+
+	<-slow.started
+
+	// While the slow printer is blocked, other groups should still be able to be created and buffer messages.
+	p := &testPrinter{}
+	SetPrinter(p)
+
+	buffered := make(chan *MessageGroup)
+	go func() {
+		b := Group("b")
+		b.V(0).Infof("b1")
+		buffered <- b
+	}()
+
+	var b *MessageGroup
+	select {
+	case b = <-buffered:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected other groups not to be blocked by a slow printer")
+	}
+
+	close(slow.gate)
+	<-ended
+	b.End()
+
+	diff := cmp.Diff([]string{"==> a", "a1"}, slow.printed())
+	if diff != "" {
+		t.Log("did not find expected messages; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+
+	if len(p.messages) != 2 {
+		t.Fatalf("expected group b to be printed, got %d messages", len(p.messages))
+	}
+}
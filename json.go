@@ -0,0 +1,154 @@
+package clout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.eth-p.dev/clout/pkg/highlight"
+)
+
+// JSONPrinter is an implementation of PrinterInterface which prints messages as JSON objects.
+// Each Message is printed as a single line, making the output suitable for consumption by other programs.
+//
+// Example output:
+//
+//     {"kind":"warning","verbosity":2,"message":"unknown key \"foo\"","fields":{"file":"config.yaml"}}
+//
+// If the JSONPrinter fails to write a message, the error is handled according to its WriteErrorPolicy.
+// By default, the program exits with code 0 if the output is a closed pipe, and other errors are ignored.
+type JSONPrinter struct {
+	writer      io.Writer
+	mutex       sync.Mutex
+	writeErrors writeErrors
+}
+
+// NewJSONPrinter creates a JSONPrinter that writes to an io.Writer.
+func NewJSONPrinter(writer io.Writer) *JSONPrinter {
+	return &JSONPrinter{
+		writer: writer,
+	}
+}
+
+// Capture returns the information that needs to be captured for messages printed by the JSONPrinter.
+// The JSONPrinter always includes the time that messages were created.
+func (p *JSONPrinter) Capture() Capture {
+	return CaptureTime
+}
+
+func (p *JSONPrinter) Print(message Message) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"kind":`)
+	writeJSONValue(&buf, message.Kind().String())
+	buf.WriteString(`,"verbosity":`)
+	writeJSONValue(&buf, message.Verbosity())
+
+	if message.Code() != "" {
+		buf.WriteString(`,"code":`)
+		writeJSONValue(&buf, message.Code())
+	}
+
+	if !message.Time().IsZero() {
+		buf.WriteString(`,"time":`)
+		writeJSONValue(&buf, message.Time())
+	}
+
+	if caller := message.Caller(); caller.File != "" {
+		buf.WriteString(`,"caller":`)
+		writeJSONValue(&buf, caller.String())
+	}
+
+	if message.Name() != "" {
+		buf.WriteString(`,"name":`)
+		writeJSONValue(&buf, message.Name())
+	}
+
+	buf.WriteString(`,"message":`)
+	writeJSONValue(&buf, message.String())
+
+	if diagnostic := message.Diagnostic(); diagnostic != nil {
+		buf.WriteString(`,"location":{"file":`)
+		writeJSONValue(&buf, diagnostic.File())
+		buf.WriteString(`,"line":`)
+		writeJSONValue(&buf, diagnostic.Line())
+		buf.WriteString(`,"column":`)
+		writeJSONValue(&buf, diagnostic.Column())
+		buf.WriteString(`}`)
+	}
+
+	if message.Err() != nil {
+		buf.WriteString(`,"error":`)
+		writeJSONValue(&buf, message.Err())
+	}
+
+	if fields := message.Fields(); len(fields) > 0 {
+		buf.WriteString(`,"fields":{`)
+		for i, field := range fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			writeJSONValue(&buf, field.Key)
+			buf.WriteByte(':')
+			writeJSONValue(&buf, field.Value)
+		}
+		buf.WriteByte('}')
+	}
+
+	buf.WriteString("}\n")
+
+	p.mutex.Lock()
+	_, err := p.writer.Write(buf.Bytes())
+	p.mutex.Unlock()
+
+	if err != nil {
+		p.writeErrors.handle(err, message, func(stderr io.Writer) error {
+			if p.writer == stderr {
+				return err
+			}
+
+			_, err := stderr.Write(buf.Bytes())
+			return err
+		})
+	}
+}
+
+// Err returns the last error that occurred while writing a message.
+func (p *JSONPrinter) Err() error {
+	return p.writeErrors.lastError()
+}
+
+// SetWriteErrorPolicy changes what the JSONPrinter does when it fails to write a message.
+func (p *JSONPrinter) SetWriteErrorPolicy(policy WriteErrorPolicy) *JSONPrinter {
+	p.writeErrors.setPolicy(policy)
+	return p
+}
+
+// SetWriteErrorHandler sets a function to call when the JSONPrinter fails to write a message.
+// This changes the WriteErrorPolicy to CallWriteErrorHandler.
+func (p *JSONPrinter) SetWriteErrorHandler(handler WriteErrorHandler) *JSONPrinter {
+	p.writeErrors.setHandler(handler)
+	return p
+}
+
+// writeJSONValue writes a value as JSON.
+// Highlights are discarded, errors are written as their message, and values that cannot be marshalled are
+// written as formatted strings.
+func writeJSONValue(buf *bytes.Buffer, value interface{}) {
+	if highlighter, ok := value.(highlight.Highlight); ok {
+		value = highlighter.Value()
+	}
+
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprintf("%+v", value))
+	}
+
+	buf.Write(encoded)
+}
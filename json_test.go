@@ -0,0 +1,55 @@
+package clout
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"go.eth-p.dev/clout/pkg/highlight"
+)
+
+func TestJSONPrinter(t *testing.T) {
+	tests := map[string]struct {
+		message  Message
+		expected string
+	}{
+		"Simple": {
+			message:  New(Info, 2, "hello %s", "world"),
+			expected: `{"kind":"info","verbosity":2,"message":"hello world"}` + "\n",
+		},
+		"Highlight": {
+			message:  New(Warning, 1, "hello %s", highlight.Cyan("world")),
+			expected: `{"kind":"warning","verbosity":1,"message":"hello world"}` + "\n",
+		},
+		"Fields": {
+			message: New(Error, 0, "failed").
+				WithFields(Field{Key: "b", Value: 1}, Field{Key: "a", Value: errors.New("oops")}),
+			expected: `{"kind":"error","verbosity":0,"message":"failed","fields":{"b":1,"a":"oops"}}` + "\n",
+		},
+		"Code": {
+			message:  New(Error, 1, "unknown key").WithCode("E0142"),
+			expected: `{"kind":"error","verbosity":1,"code":"E0142","message":"unknown key"}` + "\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			NewJSONPrinter(buf).Print(tc.message)
+
+			got := buf.String()
+			if tc.expected != got {
+				t.Fatalf("expected: %s, got: %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestJSONPrinterUnmarshallable(t *testing.T) {
+	buf := new(bytes.Buffer)
+	NewJSONPrinter(buf).Print(New(Status, 3, "chan").WithFields(Field{Key: "c", Value: make(chan int)}))
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"fields":{"c":"0x`)) {
+		t.Fatalf("expected unmarshallable value to be formatted, got: %s", buf.String())
+	}
+}
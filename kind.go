@@ -0,0 +1,220 @@
+package clout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.eth-p.dev/clout/pkg/color"
+)
+
+// Severity is the importance of a MessageKind.
+// Severities are ordered, so they can be compared to filter messages (e.g. severity >= WarningSeverity).
+type Severity int
+
+const (
+	// DebugSeverity is the severity of messages that are only useful when debugging a program.
+	DebugSeverity Severity = iota
+
+	// InfoSeverity is the severity of informational messages.
+	InfoSeverity Severity = iota
+
+	// WarningSeverity is the severity of messages about potential problems.
+	WarningSeverity Severity = iota
+
+	// ErrorSeverity is the severity of messages about problems.
+	ErrorSeverity Severity = iota
+)
+
+// String returns the lowercase name of the Severity.
+func (s Severity) String() string {
+	switch s {
+	case DebugSeverity:
+		return "debug"
+	case InfoSeverity:
+		return "info"
+	case WarningSeverity:
+		return "warning"
+	case ErrorSeverity:
+		return "error"
+	default:
+		return "severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// KindOutput describes how the printer created by NewPrinterWithDefaults prints a MessageKind.
+// The zero KindOutput prints messages to stdout without a prefix or color.
+type KindOutput struct {
+	// Stderr prints the messages to stderr instead of stdout.
+	Stderr bool
+
+	// Prefix is printed before each message (e.g. "warning:").
+	Prefix string
+
+	// PrefixColor is the color of the prefix, if colors are enabled.
+	PrefixColor color.Style
+
+	// Color is the color of the message text, if colors are enabled.
+	Color color.Style
+}
+
+// kindInfo is the registered information about a MessageKind.
+type kindInfo struct {
+	name     string
+	severity Severity
+	output   KindOutput
+}
+
+var kindRegistryMutex sync.RWMutex
+var kindRegistry []kindInfo
+
+// builtinKindsRegistered registers the built-in kinds before any init functions run.
+var builtinKindsRegistered = registerBuiltinKinds()
+
+// RegisterKind registers a new MessageKind.
+// This should be called when initializing a package, and panics if a kind with the same name is already registered.
+//
+// The default printer will be updated to print the new kind with its KindOutput. Other printers (e.g. ones created
+// before the kind was registered) will print it with their default Output unless configured with SetOutputForKind.
+//
+// Example:
+//
+//     var Skipped = clout.RegisterKind("skipped", clout.InfoSeverity, clout.KindOutput{
+//         Prefix:      "skipped:",
+//         PrefixColor: color.Foreground(color.Magenta).Bold(true),
+//     })
+func RegisterKind(name string, severity Severity, defaultOutput KindOutput) MessageKind {
+	kind := registerKind(name, severity, defaultOutput)
+	updateSettings(func(s *settings) {}) // Recreate the default printer with the new kind.
+	return kind
+}
+
+// registerKind adds a MessageKind to the registry.
+func registerKind(name string, severity Severity, defaultOutput KindOutput) MessageKind {
+	kindRegistryMutex.Lock()
+	defer kindRegistryMutex.Unlock()
+
+	for _, info := range kindRegistry {
+		if info.name == name {
+			panic(fmt.Sprintf("clout: message kind %q is already registered", name))
+		}
+	}
+
+	kindRegistry = append(kindRegistry, kindInfo{
+		name:     name,
+		severity: severity,
+		output:   defaultOutput,
+	})
+
+	return MessageKind(len(kindRegistry) - 1)
+}
+
+// registerBuiltinKinds registers the built-in MessageKind constants, in the order that they are declared.
+func registerBuiltinKinds() bool {
+	builtins := []struct {
+		kind     MessageKind
+		name     string
+		severity Severity
+		output   KindOutput
+	}{
+		{Status, "status", InfoSeverity, KindOutput{}},
+		{Info, "info", InfoSeverity, KindOutput{}},
+		{Warning, "warning", WarningSeverity, KindOutput{
+			Stderr:      true,
+			Prefix:      "warning:",
+			PrefixColor: color.Foreground(color.Yellow).Bold(true),
+			Color:       color.Foreground(color.Yellow),
+		}},
+		{Deprecation, "deprecation", WarningSeverity, KindOutput{
+			Stderr:      true,
+			Prefix:      "deprecated:",
+			PrefixColor: color.Foreground(color.Yellow).Bold(true),
+			Color:       color.Foreground(color.Yellow),
+		}},
+		{Error, "error", ErrorSeverity, KindOutput{
+			Stderr:      true,
+			Prefix:      "error:",
+			PrefixColor: color.Foreground(color.Red).Bold(true),
+			Color:       color.Foreground(color.Red),
+		}},
+		{Custom, "custom", InfoSeverity, KindOutput{}},
+		{Debug, "debug", DebugSeverity, KindOutput{
+			Stderr: true,
+			Color:  color.Plain().Dim(true),
+		}},
+		{Trace, "trace", DebugSeverity, KindOutput{
+			Stderr: true,
+			Color:  color.Plain().Dim(true),
+		}},
+		{Success, "success", InfoSeverity, KindOutput{
+			Color: color.Foreground(color.Green),
+		}},
+		{Hint, "hint", InfoSeverity, KindOutput{
+			Stderr:      true,
+			Prefix:      "hint:",
+			PrefixColor: color.Foreground(color.Cyan).Bold(true),
+		}},
+	}
+
+	for _, builtin := range builtins {
+		if kind := registerKind(builtin.name, builtin.severity, builtin.output); kind != builtin.kind {
+			panic(fmt.Sprintf("clout: built-in message kind %q registered as %d", builtin.name, kind))
+		}
+	}
+
+	return true
+}
+
+// lookupKind returns the registered information about a MessageKind.
+func lookupKind(kind MessageKind) (kindInfo, bool) {
+	kindRegistryMutex.RLock()
+	defer kindRegistryMutex.RUnlock()
+
+	if kind < 0 || int(kind) >= len(kindRegistry) {
+		return kindInfo{}, false
+	}
+
+	return kindRegistry[kind], true
+}
+
+// registeredKinds returns a copy of the registry, indexed by MessageKind.
+func registeredKinds() []kindInfo {
+	kindRegistryMutex.RLock()
+	defer kindRegistryMutex.RUnlock()
+	return append([]kindInfo(nil), kindRegistry...)
+}
+
+// String returns the registered name of the MessageKind (e.g. "warning").
+func (k MessageKind) String() string {
+	if info, ok := lookupKind(k); ok {
+		return info.name
+	}
+
+	return "kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Severity returns the registered Severity of the MessageKind.
+// Unregistered kinds have InfoSeverity.
+func (k MessageKind) Severity() Severity {
+	if info, ok := lookupKind(k); ok {
+		return info.severity
+	}
+
+	return InfoSeverity
+}
+
+// ParseKind parses the name of a registered MessageKind.
+func ParseKind(name string) (MessageKind, error) {
+	name = strings.ToLower(name)
+
+	kindRegistryMutex.RLock()
+	defer kindRegistryMutex.RUnlock()
+	for i, info := range kindRegistry {
+		if info.name == name {
+			return MessageKind(i), nil
+		}
+	}
+
+	return Custom, fmt.Errorf("unknown message kind %q", name)
+}
@@ -0,0 +1,184 @@
+package clout
+
+import (
+	"bytes"
+	"testing"
+
+	"go.eth-p.dev/clout/pkg/color"
+)
+
+func TestMessageKindString(t *testing.T) {
+	tests := map[MessageKind]string{
+		Status:          "status",
+		Info:            "info",
+		Warning:         "warning",
+		Deprecation:     "deprecation",
+		Error:           "error",
+		Custom:          "custom",
+		Debug:           "debug",
+		Trace:           "trace",
+		Success:         "success",
+		Hint:            "hint",
+		MessageKind(-1): "kind(-1)",
+	}
+
+	for kind, expected := range tests {
+		if got := kind.String(); got != expected {
+			t.Fatalf("did not find expected name for kind %d; want '%s', got '%s'", int(kind), expected, got)
+		}
+	}
+}
+
+func TestMessageKindSeverity(t *testing.T) {
+	tests := map[MessageKind]Severity{
+		Status:      InfoSeverity,
+		Info:        InfoSeverity,
+		Warning:     WarningSeverity,
+		Deprecation: WarningSeverity,
+		Error:       ErrorSeverity,
+		Custom:      InfoSeverity,
+	}
+
+	for kind, expected := range tests {
+		if got := kind.Severity(); got != expected {
+			t.Fatalf("did not find expected severity for %s; want '%s', got '%s'", kind, expected, got)
+		}
+	}
+
+	if !(DebugSeverity < InfoSeverity && InfoSeverity < WarningSeverity && WarningSeverity < ErrorSeverity) {
+		t.Fatalf("expected severities to be ordered")
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	kind, err := ParseKind("Warning")
+	if err != nil || kind != Warning {
+		t.Fatalf("expected Warning, got %s (%v)", kind, err)
+	}
+
+	if _, err := ParseKind("not-a-kind"); err == nil {
+		t.Fatalf("expected error for unknown kind")
+	}
+}
+
+// resetKinds removes the kinds registered during a test, so the test can run more than once.
+// This must be called after resetGlobals, so the default printer is reset without the removed kinds.
+func resetKinds(t *testing.T) {
+	kindRegistryMutex.RLock()
+	count := len(kindRegistry)
+	kindRegistryMutex.RUnlock()
+
+	t.Cleanup(func() {
+		kindRegistryMutex.Lock()
+		kindRegistry = kindRegistry[:count]
+		kindRegistryMutex.Unlock()
+	})
+}
+
+func TestRegisterKind(t *testing.T) {
+	resetGlobals(t)
+	resetKinds(t)
+
+	first := RegisterKind("test-first", InfoSeverity, KindOutput{})
+	second := RegisterKind("test-second", ErrorSeverity, KindOutput{Stderr: true, Prefix: "second:"})
+
+	if first == second || first == Custom || second == Custom {
+		t.Fatalf("expected registered kinds to be distinct, got %d and %d", first, second)
+	}
+
+	if parsed, _ := ParseKind("test-second"); parsed != second {
+		t.Fatalf("expected ParseKind to find the registered kind, got %s", parsed)
+	}
+
+	if second.Severity() != ErrorSeverity {
+		t.Fatalf("expected registered severity, got %s", second.Severity())
+	}
+
+	// Check that the default printer uses the KindOutput.
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	printer := newPrinterWithDefaults(OutputFromWriter(stdout), OutputFromWriter(stderr), false)
+	v := &Verbose{enabled: true, printer: printer}
+	v.Kind(first).Printf("one")
+	v.Kind(second).Printf("two")
+
+	if stdout.String() != "one\n" {
+		t.Fatalf("expected first kind on stdout, got %q", stdout.String())
+	}
+
+	if stderr.String() != "second: two\n" {
+		t.Fatalf("expected second kind on stderr, got %q", stderr.String())
+	}
+
+	// Check that registering the same name panics.
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected duplicate registration to panic")
+		}
+	}()
+
+	RegisterKind("test-first", InfoSeverity, KindOutput{PrefixColor: color.Plain()})
+}
+
+func TestVerbosePrintf(t *testing.T) {
+	p := &testPrinter{}
+	v := &Verbose{enabled: true, printer: p}
+
+	v.Printf("default")
+	v.Kind(Warning).Println("warning")
+
+	if kind := p.messages[0].Kind(); kind != Info {
+		t.Fatalf("expected Printf without Kind to print Info, got %s", kind)
+	}
+
+	if kind := p.messages[1].Kind(); kind != Warning {
+		t.Fatalf("expected Printf with Kind to print Warning, got %s", kind)
+	}
+}
+
+func TestDefaultKindOutputs(t *testing.T) {
+	tests := map[string]struct {
+		kind           MessageKind
+		expectedStdout string
+		expectedStderr string
+	}{
+		"Debug": {
+			kind:           Debug,
+			expectedStderr: "\x1B[2mhello\x1B[0m\n",
+		},
+		"Trace": {
+			kind:           Trace,
+			expectedStderr: "\x1B[2mhello\x1B[0m\n",
+		},
+		"Success": {
+			kind:           Success,
+			expectedStdout: "\x1B[32mhello\x1B[0m\n",
+		},
+		"Hint": {
+			kind:           Hint,
+			expectedStderr: "\x1B[1;36mhint:\x1B[0m hello\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			printer := newPrinterWithDefaults(
+				OutputFromWriter(stdout).WithColors(true),
+				OutputFromWriter(stderr).WithColors(true),
+				true,
+			)
+
+			printer.Print(New(tc.kind, 2, "hello"))
+
+			if stdout.String() != tc.expectedStdout {
+				t.Fatalf("expected stdout %q, got %q", tc.expectedStdout, stdout.String())
+			}
+
+			if stderr.String() != tc.expectedStderr {
+				t.Fatalf("expected stderr %q, got %q", tc.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
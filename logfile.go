@@ -0,0 +1,208 @@
+package clout
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// LogFileOptions configures the rotation and retention of a LogFile.
+type LogFileOptions struct {
+
+	// RotateOnOpen starts a new log file every time the program runs.
+	// If false, messages are appended to the existing log file.
+	RotateOnOpen bool
+
+	// MaxSize starts a new log file when writing to the current one would make it larger than this many bytes.
+	// If zero, log files are not rotated based on their size.
+	MaxSize int64
+
+	// MaxBackups is the number of old log files to keep.
+	// Old log files are named after the log file with a number appended (e.g. "app.log.1" is the most recent).
+	MaxBackups int
+}
+
+// LogFile is an io.Writer for a log file that is rotated by size or at the start of each run.
+// It is safe to write to a LogFile from multiple goroutines.
+//
+// Example:
+//
+//     logFile, err := clout.OpenLogFile("app.log", clout.LogFileOptions{RotateOnOpen: true, MaxBackups: 5})
+//     if err != nil {
+//         clout.V(0).Fatalf("failed to open log file: %v", err)
+//     }
+//
+//     defer logFile.Close()
+//     clout.SetPrinter(clout.Tee(
+//         clout.WithLogFileNote(clout.GetPrinter(), logFile),
+//         clout.NewLogFilePrinter(logFile, 10),
+//     ))
+type LogFile struct {
+	path    string
+	options LogFileOptions
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+// OpenLogFile opens a LogFile, creating it if it does not exist.
+// If RotateOnOpen is set and the file is not empty, it is rotated first.
+//
+// If the log file cannot be rotated (e.g. because another program has a backup open), messages are appended to the
+// current log file instead, and rotating is tried again on the next write that goes over the MaxSize option.
+func OpenLogFile(path string, options LogFileOptions) (*LogFile, error) {
+	f := &LogFile{
+		path:    path,
+		options: options,
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	if options.RotateOnOpen && f.size > 0 {
+		_ = f.rotate() // If the file can't be rotated, keep appending to it.
+	}
+
+	return f, nil
+}
+
+// Path returns the path of the LogFile.
+func (f *LogFile) Path() string {
+	return f.path
+}
+
+// Write writes to the LogFile, rotating it first if it would become larger than the MaxSize option.
+func (f *LogFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.options.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.options.MaxSize {
+		_ = f.rotate() // If the file can't be rotated, keep writing to it and try again later.
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the LogFile.
+func (f *LogFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// rotate moves the current log file to the first backup, and creates a new log file.
+// Backups beyond the MaxBackups option are removed.
+//
+// The current log file is only closed once the new one is open. If rotating fails, the current file is kept open so
+// that writing can continue.
+// This must be called while holding the mutex.
+func (f *LogFile) rotate() error {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if f.options.MaxBackups > 0 {
+		if err := removeIfExists(f.backupPath(f.options.MaxBackups)); err != nil {
+			return err
+		}
+
+		for i := f.options.MaxBackups - 1; i >= 1; i-- {
+			if err := renameIfExists(f.backupPath(i), f.backupPath(i+1)); err != nil {
+				return err
+			}
+		}
+
+		if err := renameIfExists(f.path, f.backupPath(1)); err != nil {
+			return err
+		}
+	} else {
+		flags |= os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(f.path, flags, 0644)
+	if err != nil {
+		return err
+	}
+
+	_ = f.file.Close()
+	f.file = file
+	f.size = 0
+	return nil
+}
+
+// backupPath returns the path of an old log file.
+func (f *LogFile) backupPath(n int) string {
+	return f.path + "." + strconv.Itoa(n)
+}
+
+// OutputFromLogFile creates a plain text Output from a LogFile.
+// Colors are never written to the log file, even if enabled with WithColors.
+func OutputFromLogFile(file *LogFile) Output {
+	output := OutputFromWriter(file).WithTimestamp(AbsoluteTimestamp)
+	output.plain = true
+	return output
+}
+
+// NewLogFilePrinter creates a Printer that writes every message up to a MessageVerbosity to a LogFile.
+//
+// Messages are written as plain text with a timestamp and the same prefixes as the default printer. Since the
+// Output instances have a max verbosity, messages above the verbosity setting are still written to the log file when
+// this is combined with the console printer using Tee.
+func NewLogFilePrinter(file *LogFile, verbosity MessageVerbosity) *Printer {
+	output := OutputFromLogFile(file).WithMaxVerbosity(verbosity)
+	return newPrinterWithDefaults(output, output, false)
+}
+
+// WithLogFileNote creates a PrinterInterface that adds "(full log at <path>)" to Error messages before printing them.
+// This can be used to tell users where to find the log file when something goes wrong.
+func WithLogFileNote(printer PrinterInterface, file *LogFile) PrinterInterface {
+	return Map(func(message Message) Message {
+		if message.Kind().Severity() < ErrorSeverity {
+			return message
+		}
+
+		message.format += " (full log at %s)"
+		message.formatArgs = append(message.formatArgs[:len(message.formatArgs):len(message.formatArgs)], file.Path())
+		return message
+	}, printer)
+}
+
+// removeIfExists removes a file, ignoring the error if it does not exist.
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// renameIfExists renames a file, ignoring the error if it does not exist.
+func renameIfExists(from string, to string) error {
+	if err := os.Rename(from, to); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
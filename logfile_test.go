@@ -0,0 +1,223 @@
+package clout
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.eth-p.dev/clout/pkg/color"
+)
+
+func readLogFiles(t *testing.T, path string) map[string]string {
+	matches, err := filepath.Glob(path + "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	for _, match := range matches {
+		data, err := os.ReadFile(match)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files[filepath.Base(match)] = string(data)
+	}
+
+	return files
+}
+
+func TestLogFile(t *testing.T) {
+	tests := map[string]struct {
+		expected map[string]string
+		existing string
+		options  LogFileOptions
+		runs     [][]string
+	}{
+		"Append": {
+			expected: map[string]string{"app.log": "old\nrun 1\nrun 2\n"},
+			existing: "old\n",
+			runs:     [][]string{{"run 1\n"}, {"run 2\n"}},
+		},
+		"Rotate On Open": {
+			expected: map[string]string{
+				"app.log":   "run 3\n",
+				"app.log.1": "run 2\n",
+				"app.log.2": "run 1\n",
+			},
+			existing: "old\n",
+			options:  LogFileOptions{RotateOnOpen: true, MaxBackups: 2},
+			runs:     [][]string{{"run 1\n"}, {"run 2\n"}, {"run 3\n"}},
+		},
+		"Rotate On Open Without Backups": {
+			expected: map[string]string{"app.log": "run 2\n"},
+			existing: "old\n",
+			options:  LogFileOptions{RotateOnOpen: true},
+			runs:     [][]string{{"run 1\n"}, {"run 2\n"}},
+		},
+		"Rotate By Size": {
+			expected: map[string]string{
+				"app.log":   "ABCDEFGH\n",
+				"app.log.1": "abcdefgh\n",
+			},
+			options: LogFileOptions{MaxSize: 10, MaxBackups: 1},
+			runs:    [][]string{{"12345678\n", "abcdefgh\n", "ABCDEFGH\n"}},
+		},
+		"Larger Than Max Size": {
+			expected: map[string]string{"app.log": "this line is too long\n"},
+			options:  LogFileOptions{MaxSize: 10},
+			runs:     [][]string{{"this line is too long\n"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.log")
+			if tc.existing != "" {
+				if err := os.WriteFile(path, []byte(tc.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, run := range tc.runs {
+				file, err := OpenLogFile(path, tc.options)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, text := range run {
+					if _, err := file.Write([]byte(text)); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				if err := file.Close(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			diff := cmp.Diff(tc.expected, readLogFiles(t, path))
+			if diff != "" {
+				t.Log("did not find expected log files; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLogFileRotateFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := OpenLogFile(path, LogFileOptions{MaxSize: 10, MaxBackups: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	// A non-empty directory in place of the backup can't be removed, so rotating fails.
+	if err := os.MkdirAll(filepath.Join(path+".1", "locked"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{"12345678\n", "abcdefgh\n"} {
+		if _, err := file.Write([]byte(text)); err != nil {
+			t.Fatalf("expected writing to continue when rotating fails, got %v", err)
+		}
+	}
+
+	// Once the backup can be replaced, rotating should work again.
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := file.Write([]byte("ABCDEFGH\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"app.log":   "ABCDEFGH\n",
+		"app.log.1": "12345678\nabcdefgh\n",
+	}
+
+	diff := cmp.Diff(expected, readLogFiles(t, path))
+	if diff != "" {
+		t.Log("did not find expected log files; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
+
+func TestLogFileClosed(t *testing.T) {
+	file, err := OpenLogFile(filepath.Join(t.TempDir(), "app.log"), LogFileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = file.Close()
+	if _, err := file.Write([]byte("hello\n")); err == nil {
+		t.Fatalf("expected an error when writing to a closed log file")
+	}
+}
+
+func TestOutputFromLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := OpenLogFile(path, LogFileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+	output := OutputFromLogFile(file).
+		WithColors(true).
+		WithColor(color.Foreground(color.Red)).
+		WithPrefix("error:", color.Foreground(color.Red))
+
+	if err := output.write(&Message{format: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readLogFiles(t, path)["app.log"]
+	if !regexp.MustCompile(`^\d{4}-\d\d-\d\d \d\d:\d\d:\d\d\.\d{3} error: hello\n$`).MatchString(got) {
+		t.Fatalf("expected plain text with a timestamp, got %q", got)
+	}
+}
+
+func TestLogFilePrinter(t *testing.T) {
+	resetGlobals(t)
+	SetVerbosity(2)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := OpenLogFile(path, LogFileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+	console := &testPrinter{}
+	SetPrinter(Tee(WithLogFileNote(console, file), NewLogFilePrinter(file, 5)))
+
+	V(0).Errorf("failed to %s", "build")
+	V(1).Warningf("careful")
+	V(5).Debugf("details")
+	V(6).Debugf("too detailed")
+
+	var gotConsole []string
+	for _, message := range console.messages {
+		gotConsole = append(gotConsole, message.String())
+	}
+
+	diff := cmp.Diff([]string{"failed to build (full log at " + path + ")", "careful"}, gotConsole)
+	if diff != "" {
+		t.Log("did not find expected console messages; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+
+	timestamps := regexp.MustCompile(`(?m)^\d{4}-\d\d-\d\d \d\d:\d\d:\d\d\.\d{3} `)
+	gotLog := timestamps.ReplaceAllString(readLogFiles(t, path)["app.log"], "")
+	diff = cmp.Diff("error: failed to build\nwarning: careful\ndetails\n", gotLog)
+	if diff != "" {
+		t.Log("did not find expected log file; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
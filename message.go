@@ -1,6 +1,6 @@
 package clout
 
-import "fmt"
+import "time"
 
 // MessageKind represents the kind of message.
 type MessageKind int
@@ -28,7 +28,24 @@ const (
 
 	// Custom is a custom message kind.
 	// This can be used with a custom printer to format special messages.
+	// To distinguish between multiple kinds of custom messages, use RegisterKind instead.
 	Custom MessageKind = iota
+
+	// Debug represents a message that is only useful when debugging the program.
+	// This should be used for internal details that the user would not normally care about.
+	Debug MessageKind = iota
+
+	// Trace represents a message that traces the program's execution in fine detail.
+	// This should be used for very noisy output, such as logging every step of a loop.
+	Trace MessageKind = iota
+
+	// Success represents a message that an action completed successfully.
+	// This should be used to tell the user that the program did what they asked.
+	Success MessageKind = iota
+
+	// Hint represents a suggestion for the user.
+	// This should be used to tell the user how to fix a problem or do something better.
+	Hint MessageKind = iota
 )
 
 // MessageVerbosity represents the verbosity level of a Message.
@@ -50,11 +67,30 @@ type Message struct {
 	formatArgs []interface{}
 	verbosity  MessageVerbosity
 	kind       MessageKind
+	fields     []Field
+	name       string
+	err        error
+	diagnostic *Diagnostic
+	code       string
+	time       time.Time
+	caller     Caller
+
+	// aboveVerbosity is true if the message is more verbose than the verbosity setting, and was only enabled
+	// because the printer's MaxVerbosity allowed it.
+	aboveVerbosity bool
+}
+
+// Field is a key/value pair attached to a Message.
+// This can be used to provide structured context (e.g. a file name) without including it in the format string.
+type Field struct {
+	Key   string
+	Value interface{}
 }
 
 // String formats the message and returns its string.
+// Any highlight.Highlight arguments will be formatted without their highlighting.
 func (m Message) String() string {
-	return fmt.Sprintf(m.format, m.formatArgs...)
+	return formatText(&m, false)
 }
 
 // Format returns the message's formatting string.
@@ -77,6 +113,77 @@ func (m Message) Kind() MessageKind {
 	return m.kind
 }
 
+// Fields returns the message's key/value fields.
+// The fields are ordered in the same order that they were added.
+func (m Message) Fields() []Field {
+	return m.fields
+}
+
+// Name returns the name of the component that printed the message.
+// This will be an empty string if the message was not printed through a named Verbose.
+func (m Message) Name() string {
+	return m.name
+}
+
+// Err returns the error attached to the message.
+// This will be nil unless the message was printed with Verbose.Err.
+func (m Message) Err() error {
+	return m.err
+}
+
+// Diagnostic returns the source location attached to the message.
+// This will be nil unless the message was printed through a Verbose created with Verbose.WithDiagnostic.
+func (m Message) Diagnostic() *Diagnostic {
+	return m.diagnostic
+}
+
+// Code returns the stable message code (e.g. "E0142").
+// This will be an empty string unless the message was printed through a Verbose created with Verbose.Code.
+func (m Message) Code() string {
+	return m.code
+}
+
+// Time returns the time that the message was created.
+// This will be the zero time.Time unless the printer requested it with CaptureTime.
+func (m Message) Time() time.Time {
+	return m.time
+}
+
+// Caller returns the location in the source code where the message was created.
+// This will be the zero Caller unless the printer requested it with CaptureCaller.
+func (m Message) Caller() Caller {
+	return m.caller
+}
+
+// WithTime creates a copy of the Message with a different creation time.
+func (m Message) WithTime(t time.Time) Message {
+	m.time = t
+	return m
+}
+
+// WithCaller creates a copy of the Message with a different caller.
+func (m Message) WithCaller(caller Caller) Message {
+	m.caller = caller
+	return m
+}
+
+// WithCode creates a copy of the Message with a different message code.
+func (m Message) WithCode(code string) Message {
+	m.code = code
+	return m
+}
+
+// WithFields creates a copy of the Message with additional key/value fields.
+// The fields are appended after any existing fields.
+func (m Message) WithFields(fields ...Field) Message {
+	if len(fields) > 0 {
+		merged := make([]Field, 0, len(m.fields)+len(fields))
+		m.fields = append(append(merged, m.fields...), fields...)
+	}
+
+	return m
+}
+
 // New creates a new Message.
 func New(kind MessageKind, verbosity MessageVerbosity, format string, args ...interface{}) Message {
 	return Message{
@@ -39,6 +39,7 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 		msg := w.Converter(text)
 		if msg != nil {
 			w.Printer.Print(*msg)
+			exitOnBrokenPipe()
 		}
 	}
 
@@ -1,8 +1,11 @@
 package clout
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 
 	"go.eth-p.dev/clout/pkg/color"
 )
@@ -11,29 +14,61 @@ import (
 type Output struct {
 	writer io.Writer
 	colors bool
+	plain  bool
 
 	terminator  string
 	color       color.Style
 	prefixColor color.Style
 	prefix      string
+	timestamp   TimestampFormat
+	caller      bool
+
+	maxVerbosity    MessageVerbosity
+	hasMaxVerbosity bool
+	minSeverity     Severity
 }
 
+// TimestampFormat is the format used to print message timestamps.
+type TimestampFormat int
+
+const (
+	// NoTimestamp does not print timestamps.
+	NoTimestamp TimestampFormat = iota
+
+	// AbsoluteTimestamp prints the local time that the message was created (e.g. "2021-06-01 12:34:56.789").
+	AbsoluteTimestamp TimestampFormat = iota
+
+	// RelativeTimestamp prints the time since the program started (e.g. "+1.234s").
+	RelativeTimestamp TimestampFormat = iota
+)
+
+// absoluteTimestampLayout is the time.Time layout used for AbsoluteTimestamp.
+const absoluteTimestampLayout = "2006-01-02 15:04:05.000"
+
 // Clone creates a copy of the Output.
 func (o Output) Clone() Output {
 	return Output{
 		writer:      o.writer,
 		colors:      o.colors,
+		plain:       o.plain,
 		color:       o.color,
 		prefix:      o.prefix,
 		prefixColor: o.prefixColor,
 		terminator:  o.terminator,
+		timestamp:   o.timestamp,
+		caller:      o.caller,
+
+		maxVerbosity:    o.maxVerbosity,
+		hasMaxVerbosity: o.hasMaxVerbosity,
+		minSeverity:     o.minSeverity,
 	}
 }
 
 // WithColors creates a copy of the Output with colors enabled/disabled.
+// Output instances created with OutputFromLogFile are always plain text.
 func (o Output) WithColors(colors bool) Output {
 	clone := o.Clone()
-	clone.colors = colors
+	clone.colors = colors && !o.plain
 	return clone
 }
 
@@ -53,13 +88,122 @@ func (o Output) WithPrefix(prefix string, color color.Style) Output {
 	return clone
 }
 
+// withWriter creates a copy of the Output that writes to a different io.Writer.
+func (o Output) withWriter(writer io.Writer) Output {
+	clone := o.Clone()
+	clone.writer = writer
+	return clone
+}
+
+// WithTimestamp creates a copy of the Output that prints the time each message was created.
+func (o Output) WithTimestamp(format TimestampFormat) Output {
+	clone := o.Clone()
+	clone.timestamp = format
+	return clone
+}
+
+// WithCaller creates a copy of the Output that prints the source file and line where each message was created.
+func (o Output) WithCaller(caller bool) Output {
+	clone := o.Clone()
+	clone.caller = caller
+	return clone
+}
+
+// WithMaxVerbosity creates a copy of the Output that only prints messages at or below a MessageVerbosity.
+//
+// This is independent of the verbosity setting: an Output with a max verbosity of 5 will receive V(5) messages even
+// if the verbosity is set to 2, while Output instances without a max verbosity only receive the messages allowed by
+// the verbosity setting.
+//
+// Example:
+//
+//     debugLog := clout.OutputFromWriter(file).WithMaxVerbosity(5)
+func (o Output) WithMaxVerbosity(verbosity MessageVerbosity) Output {
+	clone := o.Clone()
+	clone.maxVerbosity = verbosity
+	clone.hasMaxVerbosity = true
+	return clone
+}
+
+// WithMinSeverity creates a copy of the Output that only prints messages with a MessageKind of at least a Severity.
+func (o Output) WithMinSeverity(severity Severity) Output {
+	clone := o.Clone()
+	clone.minSeverity = severity
+	return clone
+}
+
+// accepts checks if a Message passes the verbosity and severity thresholds of the Output.
+func (o Output) accepts(message *Message) bool {
+	if o.hasMaxVerbosity {
+		if message.Verbosity() > o.maxVerbosity {
+			return false
+		}
+	} else if message.aboveVerbosity {
+		return false
+	}
+
+	return o.minSeverity == DebugSeverity || message.Kind().Severity() >= o.minSeverity
+}
+
+// capture returns the information that needs to be captured for messages written to the Output.
+func (o Output) capture() Capture {
+	var capture Capture
+	if o.timestamp != NoTimestamp {
+		capture |= CaptureTime
+	}
+
+	if o.caller {
+		capture |= CaptureCaller
+	}
+
+	return capture
+}
+
+// formatHeader formats the timestamp and caller of a Message into a string that is printed before the prefix.
+func (o Output) formatHeader(message *Message) string {
+	var header string
+	if o.timestamp != NoTimestamp {
+		t := message.Time()
+		if t.IsZero() {
+			t = time.Now()
+		}
+
+		switch o.timestamp {
+		case AbsoluteTimestamp:
+			header = t.Format(absoluteTimestampLayout)
+		case RelativeTimestamp:
+			header = fmt.Sprintf("+%.3fs", t.Sub(programStart).Seconds())
+		}
+	}
+
+	if caller := message.Caller().String(); o.caller && caller != "" {
+		if header != "" {
+			header += " "
+		}
+
+		header += caller
+	}
+
+	return header
+}
+
 // write writes a Message to the Output.
 //
 // This will convert the Message format string and arguments to a string,
 // then format the whole message with a Formatter if one is provided.
 func (o Output) write(message *Message) error {
 	text := formatText(message, o.colors)
+	fields := formatFields(message, o.colors)
+	if message.Name() != "" {
+		text = formatName(message, o.colors) + " " + text
+	}
+
 	prefix := o.prefix
+	if message.Code() != "" {
+		prefix = formatCodePrefix(prefix, message.Code())
+	}
+
+	hasPrefix := prefix != ""
 
 	// Apply colors.
 	if o.colors {
@@ -68,18 +212,68 @@ func (o Output) write(message *Message) error {
 	}
 
 	// Apply message prefix.
-	if o.prefix != "" {
+	if hasPrefix {
 		text = prefix + " " + text
 	}
 
+	// Apply the diagnostic location, keeping the "file:line:col: error: msg" shape that editors recognize.
+	diagnostic := message.Diagnostic()
+	if diagnostic != nil && !o.colors {
+		text = diagnostic.Location() + ": " + text
+	}
+
+	// Apply the timestamp and caller.
+	if header := o.formatHeader(message); header != "" {
+		text = header + " " + text
+	}
+
+	// Append the diagnostic.
+	var lines []string
+	if diagnostic != nil {
+		if o.colors {
+			lines = formatDiagnosticLines(diagnostic, o.prefixColor)
+		} else {
+			lines = formatDiagnosticPlainLines(diagnostic)
+		}
+	}
+
+	// Append error causes.
+	lines = append(lines, formatErrorLines(message, o.colors, o.prefixColor, o.color)...)
+
+	text = text + fields + o.terminator
+	for _, line := range lines {
+		text += line + o.terminator
+	}
+
 	// Write to the output.
-	_, err := o.writer.Write([]byte(text + o.terminator))
+	_, err := o.writer.Write([]byte(text))
 	return err
 }
 
+// formatCodePrefix adds a message code to a prefix.
+// The code is inserted before the trailing colon (e.g. "error:" becomes "error[E0142]:").
+func formatCodePrefix(prefix string, code string) string {
+	if strings.HasSuffix(prefix, ":") {
+		return prefix[:len(prefix)-1] + "[" + code + "]:"
+	}
+
+	if prefix == "" {
+		return "[" + code + "]"
+	}
+
+	return prefix + "[" + code + "]"
+}
+
 // OutputFromFile creates a Output from an os.File.
 // If the file is a terminal, colors will be enabled.
+//
+// If the file is os.Stdout or os.Stderr, SIGPIPE is handled so that writing to a closed pipe returns an error instead
+// of killing the program (see ExitOnBrokenPipe).
 func OutputFromFile(file *os.File) Output {
+	if file == os.Stdout || file == os.Stderr {
+		handleSIGPIPE()
+	}
+
 	colorsSupported := supportsColor(file)
 	return OutputFromWriter(file).
 		WithColors(colorsSupported)
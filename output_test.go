@@ -3,6 +3,7 @@ package clout
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"go.eth-p.dev/clout/pkg/color"
 )
@@ -32,6 +33,113 @@ func TestOutput(t *testing.T) {
 					WithColors(true)
 			},
 		},
+		"With Fields": {
+			expected: "hello world a=1 b=\"two words\"\n",
+			message: Message{
+				format: "hello world",
+				fields: []Field{{Key: "a", Value: 1}, {Key: "b", Value: "two words"}},
+			},
+			init: func(output Output) Output {
+				return output.WithColors(false)
+			},
+		},
+		"With Fields And Colors": {
+			expected: "\x1B[31mhello world\x1B[0m \x1B[36ma\x1B[0m=1\n",
+			message: Message{
+				format: "hello world",
+				fields: []Field{{Key: "a", Value: 1}},
+			},
+			init: func(output Output) Output {
+				return output.
+					WithColor(color.Foreground(color.Red)).
+					WithColors(true)
+			},
+		},
+		"With Name": {
+			expected: "error: outer/inner: hello world\n",
+			message: Message{
+				format: "hello world",
+				name:   "outer/inner",
+			},
+			init: func(output Output) Output {
+				return output.
+					WithPrefix("error:", color.Plain()).
+					WithColors(false)
+			},
+		},
+		"With Code": {
+			expected: "error[E0142]: hello world\n",
+			message: Message{
+				format: "hello world",
+				code:   "E0142",
+			},
+			init: func(output Output) Output {
+				return output.WithPrefix("error:", color.Plain())
+			},
+		},
+		"With Code And Colors": {
+			expected: "\x1B[1;31merror[E0142]:\x1B[0m hello world\n",
+			message: Message{
+				format: "hello world",
+				code:   "E0142",
+			},
+			init: func(output Output) Output {
+				return output.
+					WithPrefix("error:", color.Foreground(color.Red).Bold(true)).
+					WithColors(true)
+			},
+		},
+		"With Code Without Prefix": {
+			expected: "[E0142] hello world\n",
+			message: Message{
+				format: "hello world",
+				code:   "E0142",
+			},
+			init: func(output Output) Output {
+				return output
+			},
+		},
+		"With Absolute Timestamp": {
+			expected: "2021-06-01 12:34:56.789 error: hello world\n",
+			message: Message{
+				format: "hello world",
+				time:   time.Date(2021, 6, 1, 12, 34, 56, 789000000, time.Local),
+			},
+			init: func(output Output) Output {
+				return output.
+					WithPrefix("error:", color.Plain()).
+					WithTimestamp(AbsoluteTimestamp)
+			},
+		},
+		"With Relative Timestamp": {
+			expected: "+1.234s hello world\n",
+			message: Message{
+				format: "hello world",
+				time:   programStart.Add(1234 * time.Millisecond),
+			},
+			init: func(output Output) Output {
+				return output.WithTimestamp(RelativeTimestamp)
+			},
+		},
+		"With Caller": {
+			expected: "main.go:42 error: hello world\n",
+			message: Message{
+				format: "hello world",
+				caller: Caller{File: "/src/cmd/main.go", Line: 42, Function: "main.main"},
+			},
+			init: func(output Output) Output {
+				return output.
+					WithPrefix("error:", color.Plain()).
+					WithCaller(true)
+			},
+		},
+		"With Caller Unknown": {
+			expected: "hello world\n",
+			message:  Message{format: "hello world"},
+			init: func(output Output) Output {
+				return output.WithCaller(true)
+			},
+		},
 		"Without Colors": {
 			expected: "error: hello world\n",
 			message:  New(Info, 2, "hello world"),
@@ -0,0 +1,164 @@
+package clout
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"go.eth-p.dev/clout/pkg/highlight"
+)
+
+// PanicExitCode is the default exit code used when a panic is reported.
+// This is the same as EX_SOFTWARE from sysexits.h.
+const PanicExitCode = 70
+
+// defaultPanicTraceVerbosity is the default MessageVerbosity required to print the stack trace of a panic.
+const defaultPanicTraceVerbosity MessageVerbosity = 4
+
+// PanicReporter reports recovered panics through clout.
+//
+// When a panic is recovered, a user-friendly Error message will be printed at V(0).
+// The stack trace is only printed if the verbosity is high enough, but it can also be written to a crash file.
+type PanicReporter struct {
+	traceVerbosity MessageVerbosity
+	crashFile      string
+	exitCode       int
+	repanic        bool
+}
+
+// NewPanicReporter creates a PanicReporter with default settings.
+// It will print the stack trace at V(4), and exit with PanicExitCode.
+func NewPanicReporter() *PanicReporter {
+	return &PanicReporter{
+		traceVerbosity: defaultPanicTraceVerbosity,
+		exitCode:       PanicExitCode,
+	}
+}
+
+// SetTraceVerbosity changes the minimum MessageVerbosity required to print the stack trace.
+func (r *PanicReporter) SetTraceVerbosity(verbosity MessageVerbosity) *PanicReporter {
+	r.traceVerbosity = verbosity
+	return r
+}
+
+// SetCrashFile changes the path of the file that the full stack trace is written to.
+// If the path is empty, no crash file will be written.
+func (r *PanicReporter) SetCrashFile(path string) *PanicReporter {
+	r.crashFile = path
+	return r
+}
+
+// SetExitCode changes the exit code used after the panic is reported.
+func (r *PanicReporter) SetExitCode(code int) *PanicReporter {
+	r.exitCode = code
+	return r
+}
+
+// SetRepanic changes whether the panic is re-raised after it is reported, instead of exiting.
+func (r *PanicReporter) SetRepanic(repanic bool) *PanicReporter {
+	r.repanic = repanic
+	return r
+}
+
+// Recover recovers from a panic and reports it.
+// This must be called directly with defer.
+//
+// Example:
+//
+//     defer clout.NewPanicReporter().SetCrashFile("crash.log").Recover()
+func (r *PanicReporter) Recover() {
+	if value := recover(); value != nil {
+		r.report(value)
+	}
+}
+
+// RecoverAndReport recovers from a panic and reports it with the default PanicReporter settings.
+// This must be called directly with defer.
+//
+// Example:
+//
+//     func main() {
+//         defer clout.RecoverAndReport()
+//         // ...
+//     }
+func RecoverAndReport() {
+	if value := recover(); value != nil {
+		NewPanicReporter().report(value)
+	}
+}
+
+// report prints a recovered panic value, then exits or re-panics.
+func (r *PanicReporter) report(value interface{}) {
+	v := V(0)
+	v.Errorf("the program crashed unexpectedly: %v", value)
+
+	// Write the crash file.
+	if r.crashFile != "" {
+		if err := writeCrashFile(r.crashFile, value); err != nil {
+			v.Errorf("unable to write crash report: %v", err)
+		} else {
+			v.Errorf("a crash report was written to %s", highlight.Cyan(r.crashFile))
+		}
+	}
+
+	// Print the stack trace.
+	if trace := V(r.traceVerbosity); trace.Enabled() {
+		format, args := formatPanicTrace(panicFrames())
+		trace.Errorf(format, args...)
+	}
+
+	if r.repanic {
+		panic(value)
+	}
+
+	exitPrinter(v.printer, r.exitCode)
+}
+
+// writeCrashFile writes a panic value and the full stack trace of the current goroutine to a file.
+func writeCrashFile(path string, value interface{}) error {
+	report := fmt.Sprintf("panic: %v\n\n%s", value, debug.Stack())
+	return os.WriteFile(path, []byte(report), 0644)
+}
+
+// panicFrames returns the stack frames of the code that caused the current panic.
+// Frames belonging to the Go runtime and the PanicReporter are filtered out.
+func panicFrames() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(1, pcs)]
+
+	var result []runtime.Frame
+	var panicked bool
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+
+		// Everything before runtime.gopanic belongs to the deferred reporter.
+		if !panicked {
+			panicked = frame.Function == "runtime.gopanic"
+		} else if !strings.HasPrefix(frame.Function, "runtime.") {
+			result = append(result, frame)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+// formatPanicTrace creates a format string and arguments for printing stack frames.
+func formatPanicTrace(frames []runtime.Frame) (string, []interface{}) {
+	var sb strings.Builder
+	args := make([]interface{}, 0, len(frames)*3)
+
+	sb.WriteString("stack trace:")
+	for _, frame := range frames {
+		sb.WriteString("\n    %s()\n        %s:%d")
+		args = append(args, highlight.Cyan(frame.Function), frame.File, frame.Line)
+	}
+
+	return sb.String(), args
+}
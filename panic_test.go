@@ -0,0 +1,106 @@
+package clout
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// panicWith panics with a value, recovering with a PanicReporter.
+func panicWith(r *PanicReporter, value interface{}) {
+	defer r.Recover()
+	panic(value)
+}
+
+func TestPanicReporter(t *testing.T) {
+	resetGlobals(t)
+	code := captureExit(t)
+
+	p := &testPrinter{}
+	SetPrinter(p)
+
+	panicWith(NewPanicReporter(), "boom")
+
+	if *code != PanicExitCode {
+		t.Fatalf("expected exit code %d, got %d", PanicExitCode, *code)
+	}
+
+	if len(p.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(p.messages))
+	}
+
+	if got := p.messages[0].String(); got != "the program crashed unexpectedly: boom" {
+		t.Fatalf("unexpected message: %q", got)
+	}
+}
+
+func TestPanicReporterTrace(t *testing.T) {
+	resetGlobals(t)
+	captureExit(t)
+
+	p := &testPrinter{}
+	SetPrinter(p)
+	SetVerbosity(4)
+
+	panicWith(NewPanicReporter().SetExitCode(3), "boom")
+
+	if len(p.messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(p.messages))
+	}
+
+	trace := p.messages[1].String()
+	if !strings.HasPrefix(trace, "stack trace:\n") {
+		t.Fatalf("expected stack trace, got %q", trace)
+	}
+
+	if !strings.Contains(trace, "go.eth-p.dev/clout.panicWith()") {
+		t.Fatalf("expected stack trace to contain the panicking function, got %q", trace)
+	}
+
+	if strings.Contains(trace, "runtime.") || strings.Contains(trace, "(*PanicReporter)") {
+		t.Fatalf("expected stack trace to be filtered, got %q", trace)
+	}
+}
+
+func TestPanicReporterCrashFile(t *testing.T) {
+	resetGlobals(t)
+	captureExit(t)
+
+	p := &testPrinter{}
+	SetPrinter(p)
+
+	crashFile := filepath.Join(t.TempDir(), "crash.log")
+	panicWith(NewPanicReporter().SetCrashFile(crashFile), "boom")
+
+	contents, err := os.ReadFile(crashFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(string(contents), "panic: boom\n") {
+		t.Fatalf("unexpected crash file contents: %q", contents)
+	}
+
+	if len(p.messages) != 2 || !strings.Contains(p.messages[1].String(), crashFile) {
+		t.Fatalf("expected message with crash file path, got %v", p.messages)
+	}
+}
+
+func TestPanicReporterRepanic(t *testing.T) {
+	resetGlobals(t)
+	code := captureExit(t)
+	SetPrinter(&testPrinter{})
+
+	defer func() {
+		if value := recover(); value != "boom" {
+			t.Fatalf("expected re-panic with \"boom\", got %v", value)
+		}
+
+		if *code != -1 {
+			t.Fatalf("expected no exit, got exit code %d", *code)
+		}
+	}()
+
+	panicWith(NewPanicReporter().SetRepanic(true), "boom")
+}
@@ -0,0 +1,11 @@
+module go.eth-p.dev/clout/pkg/cloutlogr
+
+go 1.16
+
+// This must require a released version of clout with Verbose.WithName and Verbose.WithCallDepth.
+// To develop against the local copy of clout, create the go.work file with "make go.work".
+require (
+	github.com/go-logr/logr v1.4.2
+	github.com/google/go-cmp v0.5.6
+	go.eth-p.dev/clout v0.1.0
+)
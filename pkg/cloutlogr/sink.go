@@ -0,0 +1,96 @@
+package cloutlogr
+
+import (
+	"github.com/go-logr/logr"
+	"go.eth-p.dev/clout"
+)
+
+// LogSink is an implementation of logr.LogSink that prints through clout.
+//
+// Info messages logged with V(level) are printed as clout.Info messages with the same clout.MessageVerbosity,
+// and errors are printed as clout.Error messages at V(0) with the error attached as the "err" field.
+type LogSink struct {
+	printer       clout.PrinterInterface
+	name          string
+	keysAndValues []interface{}
+	callDepth     int
+}
+
+var _ logr.CallDepthLogSink = (*LogSink)(nil)
+
+// New creates a logr.Logger that prints through clout.
+// If the printer is nil, the global printer from clout.GetPrinter will be used.
+func New(printer clout.PrinterInterface) logr.Logger {
+	return logr.New(NewLogSink(printer))
+}
+
+// NewLogSink creates a LogSink that prints through clout.
+// If the printer is nil, the global printer from clout.GetPrinter will be used.
+func NewLogSink(printer clout.PrinterInterface) *LogSink {
+	return &LogSink{
+		printer: printer,
+	}
+}
+
+// Init receives runtime info about the logr library.
+func (s *LogSink) Init(info logr.RuntimeInfo) {
+	s.callDepth = info.CallDepth
+}
+
+// Enabled returns true if a message of the given level would be printed by the LogSink's printer.
+// This takes clout.SetVModule overrides for the caller of the logr.Logger into account.
+func (s *LogSink) Enabled(level int) bool {
+	return clout.Logger{}.WithPrinter(s.printer).VDepth(s.callDepth+1, clout.MessageVerbosity(level)).Enabled()
+}
+
+// Info prints an Info message.
+func (s *LogSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.verbose(clout.MessageVerbosity(level)).
+		WithValues(keysAndValues...).
+		Infof("%s", msg)
+}
+
+// Error prints an Error message with the error attached.
+func (s *LogSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.verbose(0).
+		WithValues(keysAndValues...).
+		WithValues("err", err).
+		Errorf("%s", msg)
+}
+
+// WithValues creates a copy of the LogSink with additional key/value pairs.
+func (s *LogSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	clone := *s
+	clone.keysAndValues = make([]interface{}, 0, len(s.keysAndValues)+len(keysAndValues))
+	clone.keysAndValues = append(clone.keysAndValues, s.keysAndValues...)
+	clone.keysAndValues = append(clone.keysAndValues, keysAndValues...)
+	return &clone
+}
+
+// WithCallDepth creates a copy of the LogSink that skips additional stack frames when finding its caller.
+func (s *LogSink) WithCallDepth(depth int) logr.LogSink {
+	clone := *s
+	clone.callDepth += depth
+	return &clone
+}
+
+// WithName creates a copy of the LogSink with a name appended to its existing name.
+func (s *LogSink) WithName(name string) logr.LogSink {
+	clone := *s
+	if s.name != "" {
+		clone.name = s.name + "/" + name
+	} else {
+		clone.name = name
+	}
+
+	return &clone
+}
+
+// verbose creates a clout.Verbose with the name, values, and printer of the LogSink.
+// This must be called directly from a logr.LogSink method to find the correct caller.
+func (s *LogSink) verbose(verbosity clout.MessageVerbosity) *clout.Verbose {
+	return clout.Logger{}.WithPrinter(s.printer).VDepth(s.callDepth+2, verbosity).
+		WithName(s.name).
+		WithValues(s.keysAndValues...).
+		WithCallDepth(s.callDepth + 1)
+}
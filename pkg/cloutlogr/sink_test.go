@@ -0,0 +1,164 @@
+package cloutlogr
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.eth-p.dev/clout"
+)
+
+type testPrinter struct {
+	messages []clout.Message
+}
+
+func (p *testPrinter) Print(message clout.Message) {
+	p.messages = append(p.messages, message)
+}
+
+type testMessage struct {
+	Kind      clout.MessageKind
+	Verbosity clout.MessageVerbosity
+	Name      string
+	Text      string
+	Fields    []clout.Field
+}
+
+func TestLogSink(t *testing.T) {
+	testErr := errors.New("oops")
+	tests := map[string]struct {
+		expected []testMessage
+		fn       func(p clout.PrinterInterface)
+	}{
+		"Info": {
+			expected: []testMessage{{Kind: clout.Info, Verbosity: 1, Text: "hello"}},
+			fn: func(p clout.PrinterInterface) {
+				New(p).V(1).Info("hello")
+			},
+		},
+		"Info Disabled": {
+			expected: nil,
+			fn: func(p clout.PrinterInterface) {
+				New(p).V(5).Info("hello")
+			},
+		},
+		"Error": {
+			expected: []testMessage{{
+				Kind:   clout.Error,
+				Text:   "failed",
+				Fields: []clout.Field{{Key: "a", Value: 1}, {Key: "err", Value: testErr}},
+			}},
+			fn: func(p clout.PrinterInterface) {
+				New(p).Error(testErr, "failed", "a", 1)
+			},
+		},
+		"WithName": {
+			expected: []testMessage{{Kind: clout.Info, Name: "outer/inner", Text: "hello"}},
+			fn: func(p clout.PrinterInterface) {
+				New(p).WithName("outer").WithName("inner").Info("hello")
+			},
+		},
+		"WithValues": {
+			expected: []testMessage{{
+				Kind:   clout.Info,
+				Text:   "hello",
+				Fields: []clout.Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+			}},
+			fn: func(p clout.PrinterInterface) {
+				New(p).WithValues("a", 1).Info("hello", "b", 2)
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &testPrinter{}
+			tc.fn(p)
+
+			var got []testMessage
+			for _, message := range p.messages {
+				got = append(got, testMessage{
+					Kind:      message.Kind(),
+					Verbosity: message.Verbosity(),
+					Name:      message.Name(),
+					Text:      message.String(),
+					Fields:    message.Fields(),
+				})
+			}
+
+			diff := cmp.Diff(tc.expected, got, cmp.Comparer(func(a, b error) bool { return a == b }))
+			if diff != "" {
+				t.Log("did not find expected Message; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+type testVerbosityPrinter struct {
+	testPrinter
+}
+
+func (p *testVerbosityPrinter) MaxVerbosity() (clout.MessageVerbosity, bool) {
+	return 5, true
+}
+
+func TestLogSinkPrinterMaxVerbosity(t *testing.T) {
+	verbosity := clout.GetVerbosity()
+	clout.SetVerbosity(2)
+	defer clout.SetVerbosity(verbosity)
+
+	p := &testVerbosityPrinter{}
+	logger := New(p)
+	if !logger.V(5).Enabled() || logger.V(6).Enabled() {
+		t.Fatalf("expected V(5) to be enabled by the printer's MaxVerbosity")
+	}
+
+	logger.V(5).Info("shown")
+	logger.V(6).Info("hidden")
+	if len(p.messages) != 1 || p.messages[0].String() != "shown" {
+		t.Fatalf("expected only the V(5) message to be printed, got %v", p.messages)
+	}
+}
+
+type testCapturingPrinter struct {
+	testPrinter
+}
+
+func (p *testCapturingPrinter) Capture() clout.Capture {
+	return clout.CaptureCaller
+}
+
+func TestLogSinkCaller(t *testing.T) {
+	tests := map[string]struct {
+		fn func(p clout.PrinterInterface)
+	}{
+		"Info": {fn: func(p clout.PrinterInterface) {
+			New(p).Info("hello")
+		}},
+		"Error": {fn: func(p clout.PrinterInterface) {
+			New(p).Error(nil, "hello")
+		}},
+		"WithCallDepth": {fn: func(p clout.PrinterInterface) {
+			logger := New(p)
+			func() { logger.WithCallDepth(1).Info("hello") }()
+		}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &testCapturingPrinter{}
+			tc.fn(p)
+
+			if len(p.messages) != 1 {
+				t.Fatalf("expected 1 message, got %d", len(p.messages))
+			}
+
+			caller := p.messages[0].Caller()
+			if filepath.Base(caller.File) != "sink_test.go" {
+				t.Fatalf("expected caller in sink_test.go, got %q (%s)", caller.File, caller.Function)
+			}
+		})
+	}
+}
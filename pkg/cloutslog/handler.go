@@ -0,0 +1,167 @@
+//go:build go1.21
+// +build go1.21
+
+package cloutslog
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+
+	"go.eth-p.dev/clout"
+)
+
+// Handler is an implementation of slog.Handler that prints records through a clout.PrinterInterface.
+//
+// Record levels are converted to a clout.MessageKind and clout.MessageVerbosity, and record attributes are converted
+// to clout.Field key/value pairs. Attributes inside of groups are given dot-separated keys (e.g. "request.id").
+type Handler struct {
+	printer clout.PrinterInterface
+	fields  []clout.Field
+	group   string
+}
+
+// NewHandler creates a Handler that prints to a clout.PrinterInterface.
+// If the printer is nil, the global printer from clout.GetPrinter will be used.
+func NewHandler(printer clout.PrinterInterface) *Handler {
+	return &Handler{
+		printer: printer,
+	}
+}
+
+// Enabled returns true if a record of the given level would be printed.
+// This follows the same rules as clout.VDepth, including vmodule overrides for the code that called slog.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	_, verbosity := levelToMessage(level)
+	return h.verbose(slogCallerDepth(), verbosity).Enabled()
+}
+
+// Handle prints a record as a clout.Message.
+func (h *Handler) Handle(_ context.Context, record slog.Record) error {
+	kind, verbosity := levelToMessage(record.Level)
+
+	fields := make([]clout.Field, 0, len(h.fields)+record.NumAttrs())
+	fields = append(fields, h.fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, h.group, attr)
+		return true
+	})
+
+	message := clout.New(kind, verbosity, "%s", record.Message).
+		WithFields(fields...).
+		WithTime(record.Time)
+
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		message = message.WithCaller(clout.Caller{File: frame.File, Line: frame.Line, Function: frame.Function})
+	}
+
+	h.verbose(slogCallerDepth(), verbosity).PrintMessage(message)
+	return nil
+}
+
+// WithAttrs creates a copy of the Handler with additional attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.fields = make([]clout.Field, 0, len(h.fields)+len(attrs))
+	clone.fields = append(clone.fields, h.fields...)
+	for _, attr := range attrs {
+		clone.fields = appendAttr(clone.fields, h.group, attr)
+	}
+
+	return &clone
+}
+
+// WithGroup creates a copy of the Handler where all subsequent attributes are inside of a group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.group = groupKey(h.group, name)
+	return &clone
+}
+
+// verbose creates a clout.Verbose for printing to the Handler's printer.
+// The depth is relative to the caller of verbose.
+func (h *Handler) verbose(depth int, verbosity clout.MessageVerbosity) *clout.Verbose {
+	return clout.Logger{}.WithPrinter(h.printer).VDepth(depth+1, verbosity)
+}
+
+// slogCallerDepth returns the depth of the first caller outside of the log/slog package, relative to the caller of
+// slogCallerDepth. The depth changes depending on which slog function was used, so it can't be a constant.
+func slogCallerDepth() int {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:]) // Skip runtime.Callers, slogCallerDepth, and its caller.
+	frames := runtime.CallersFrames(pcs[:n])
+
+	depth := 1
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "log/slog.") {
+			return depth
+		}
+
+		depth++
+	}
+}
+
+// appendAttr appends a slog.Attr to a slice of clout.Field instances.
+// Group attributes are flattened, and empty attributes are discarded.
+func appendAttr(fields []clout.Field, group string, attr slog.Attr) []clout.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		subgroup := group
+		if attr.Key != "" {
+			subgroup = groupKey(group, attr.Key)
+		}
+
+		for _, child := range attr.Value.Group() {
+			fields = append(fields, appendAttr(nil, subgroup, child)...)
+		}
+
+		return fields
+	}
+
+	return append(fields, clout.Field{
+		Key:   groupKey(group, attr.Key),
+		Value: attr.Value.Any(),
+	})
+}
+
+// groupKey joins a group name and key with a dot.
+func groupKey(group string, key string) string {
+	if group == "" {
+		return key
+	}
+
+	return group + "." + key
+}
+
+// levelToMessage converts a slog.Level to the clout.MessageKind and clout.MessageVerbosity used to print it.
+//
+//   LevelError and above  -> Error, V(1)
+//   LevelWarn and above   -> Warning, V(1)
+//   LevelInfo and above   -> Info, V(2)
+//   LevelDebug and above  -> Debug, V(4)
+//   Below LevelDebug      -> Trace, V(5)
+func levelToMessage(level slog.Level) (clout.MessageKind, clout.MessageVerbosity) {
+	switch {
+	case level >= slog.LevelError:
+		return clout.Error, 1
+	case level >= slog.LevelWarn:
+		return clout.Warning, 1
+	case level >= slog.LevelInfo:
+		return clout.Info, 2
+	case level >= slog.LevelDebug:
+		return clout.Debug, 4
+	default:
+		return clout.Trace, 5
+	}
+}
@@ -0,0 +1,275 @@
+//go:build go1.21
+// +build go1.21
+
+package cloutslog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.eth-p.dev/clout"
+)
+
+type testPrinter struct {
+	messages []clout.Message
+}
+
+func (p *testPrinter) Print(message clout.Message) {
+	p.messages = append(p.messages, message)
+}
+
+type testMessage struct {
+	Kind      clout.MessageKind
+	Verbosity clout.MessageVerbosity
+	Text      string
+	Fields    []clout.Field
+}
+
+func TestHandler(t *testing.T) {
+	tests := map[string]struct {
+		expected []testMessage
+		fn       func(logger *slog.Logger)
+	}{
+		"Info": {
+			expected: []testMessage{{Kind: clout.Info, Verbosity: 2, Text: "hello"}},
+			fn: func(logger *slog.Logger) {
+				logger.Info("hello")
+			},
+		},
+		"Error": {
+			expected: []testMessage{{Kind: clout.Error, Verbosity: 1, Text: "failed"}},
+			fn: func(logger *slog.Logger) {
+				logger.Error("failed")
+			},
+		},
+		"Warn": {
+			expected: []testMessage{{Kind: clout.Warning, Verbosity: 1, Text: "careful"}},
+			fn: func(logger *slog.Logger) {
+				logger.Warn("careful")
+			},
+		},
+		"Attrs": {
+			expected: []testMessage{{
+				Kind:      clout.Info,
+				Verbosity: 2,
+				Text:      "hello",
+				Fields:    []clout.Field{{Key: "a", Value: int64(1)}, {Key: "b", Value: "two"}},
+			}},
+			fn: func(logger *slog.Logger) {
+				logger.With("a", 1).Info("hello", "b", "two")
+			},
+		},
+		"Groups": {
+			expected: []testMessage{{
+				Kind:      clout.Info,
+				Verbosity: 2,
+				Text:      "hello",
+				Fields: []clout.Field{
+					{Key: "a", Value: int64(1)},
+					{Key: "req.b", Value: int64(2)},
+					{Key: "req.inner.c", Value: int64(3)},
+				},
+			}},
+			fn: func(logger *slog.Logger) {
+				logger.With("a", 1).WithGroup("req").Info("hello", "b", 2, slog.Group("inner", "c", 3))
+			},
+		},
+		"Disabled": {
+			expected: nil,
+			fn: func(logger *slog.Logger) {
+				logger.Debug("hidden")
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &testPrinter{}
+			tc.fn(slog.New(NewHandler(p)))
+
+			var got []testMessage
+			for _, message := range p.messages {
+				got = append(got, testMessage{
+					Kind:      message.Kind(),
+					Verbosity: message.Verbosity(),
+					Text:      message.String(),
+					Fields:    message.Fields(),
+				})
+			}
+
+			diff := cmp.Diff(tc.expected, got)
+			if diff != "" {
+				t.Log("did not find expected Message; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+type testVerbosityPrinter struct {
+	testPrinter
+	max clout.MessageVerbosity
+}
+
+func (p *testVerbosityPrinter) MaxVerbosity() (clout.MessageVerbosity, bool) {
+	return p.max, true
+}
+
+func TestHandlerVerbosity(t *testing.T) {
+	tests := map[string]struct {
+		vmodule      string
+		maxVerbosity clout.MessageVerbosity
+		expected     []string
+	}{
+		"Default": {
+			expected: []string{"info"},
+		},
+		"VModule Caller": {
+			vmodule:  "handler_test=4",
+			expected: []string{"info", "debug", "debug context", "debug log"},
+		},
+		"VModule Handler": {
+			vmodule:  "handler=5",
+			expected: []string{"info"},
+		},
+		"Printer MaxVerbosity": {
+			maxVerbosity: 5,
+			expected:     []string{"info", "debug", "debug context", "debug log", "trace"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := clout.SetVModule(tc.vmodule); err != nil {
+				t.Fatal(err)
+			}
+
+			defer clout.SetVModule("")
+
+			p := &testVerbosityPrinter{max: tc.maxVerbosity}
+			logger := slog.New(NewHandler(p))
+			logger.Info("info")
+			logger.Debug("debug")
+			logger.DebugContext(context.Background(), "debug context")
+			logger.Log(context.Background(), slog.LevelDebug, "debug log")
+			logger.Log(context.Background(), slog.LevelDebug-4, "trace")
+
+			var got []string
+			for _, message := range p.messages {
+				got = append(got, message.String())
+			}
+
+			diff := cmp.Diff(tc.expected, got)
+			if diff != "" {
+				t.Log("did not find expected Message; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+type testHandler struct {
+	records []slog.Record
+}
+
+func (h *testHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *testHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *testHandler) WithGroup(string) slog.Handler            { return h }
+func (h *testHandler) Handle(_ context.Context, record slog.Record) error {
+	h.records = append(h.records, record)
+	return nil
+}
+
+func TestPrinter(t *testing.T) {
+	h := &testHandler{}
+	p := NewPrinter(h)
+
+	p.Print(clout.New(clout.Warning, 2, "hello %s", "world").WithFields(clout.Field{Key: "a", Value: 1}))
+	p.Print(clout.New(clout.Status, 5, "trace"))
+
+	if len(h.records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(h.records))
+	}
+
+	if h.records[0].Level != slog.LevelWarn || h.records[0].Message != "hello world" {
+		t.Fatalf("unexpected record: %v %q", h.records[0].Level, h.records[0].Message)
+	}
+
+	var attrs []string
+	h.records[0].Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr.String())
+		return true
+	})
+
+	if diff := cmp.Diff([]string{"a=1"}, attrs); diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if h.records[1].Level != slog.LevelDebug-4 {
+		t.Fatalf("expected trace level, got %v", h.records[1].Level)
+	}
+}
+
+func TestPrinterMessageData(t *testing.T) {
+	h := &testHandler{}
+	p := NewPrinter(h).SetAddSource(true)
+	testErr := errors.New("oops")
+
+	when := time.Now()
+	clout.Logger{}.WithPrinter(p).WithName("outer").V(0).Code("E0001").WithValues("a", 1).Err(testErr)
+
+	if len(h.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(h.records))
+	}
+
+	record := h.records[0]
+	if record.Time.Before(when) || record.Time.After(time.Now()) {
+		t.Fatalf("expected record to have the message time, got %v", record.Time)
+	}
+
+	var attrs []string
+	var source *slog.Source
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == slog.SourceKey {
+			source, _ = attr.Value.Any().(*slog.Source)
+			return true
+		}
+
+		attrs = append(attrs, attr.String())
+		return true
+	})
+
+	if diff := cmp.Diff([]string{"name=outer", "code=E0001", "error=oops", "a=1"}, attrs); diff != "" {
+		t.Log("did not find expected attributes; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+
+	if source == nil || filepath.Base(source.File) != "handler_test.go" {
+		t.Fatalf("expected source in handler_test.go, got %+v", source)
+	}
+}
+
+func TestLevelToMessage(t *testing.T) {
+	tests := map[slog.Level]struct {
+		kind      clout.MessageKind
+		verbosity clout.MessageVerbosity
+	}{
+		slog.LevelError:     {kind: clout.Error, verbosity: 1},
+		slog.LevelWarn:      {kind: clout.Warning, verbosity: 1},
+		slog.LevelInfo:      {kind: clout.Info, verbosity: 2},
+		slog.LevelDebug:     {kind: clout.Debug, verbosity: 4},
+		slog.LevelDebug - 4: {kind: clout.Trace, verbosity: 5},
+	}
+
+	for level, tc := range tests {
+		kind, verbosity := levelToMessage(level)
+		if kind != tc.kind || verbosity != tc.verbosity {
+			t.Fatalf("expected %v to be %s at V(%d), got %s at V(%d)", level, tc.kind, tc.verbosity, kind, verbosity)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+//go:build go1.21
+// +build go1.21
+
+package cloutslog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.eth-p.dev/clout"
+)
+
+// Printer is an implementation of clout.PrinterInterface that forwards messages to a slog.Handler.
+// This can be used to send the output of libraries using clout into a structured logging pipeline.
+//
+// The message's name, code, and error are added as "name", "code", and "error" attributes, followed by its fields.
+type Printer struct {
+	handler   slog.Handler
+	addSource bool
+}
+
+// NewPrinter creates a Printer that forwards messages to a slog.Handler.
+func NewPrinter(handler slog.Handler) *Printer {
+	return &Printer{
+		handler: handler,
+	}
+}
+
+// SetAddSource sets whether the caller of each message is captured and added to the record as a slog.Source
+// attribute with the slog.SourceKey key. This is disabled by default, since capturing the caller is slow.
+//
+// The record's PC is not set, since a clout.Caller can't be converted back to a program counter.
+func (p *Printer) SetAddSource(addSource bool) *Printer {
+	p.addSource = addSource
+	return p
+}
+
+// Capture returns the information that needs to be captured for messages printed by the Printer.
+// The time is always captured, and the caller is captured if SetAddSource is enabled.
+func (p *Printer) Capture() clout.Capture {
+	if p.addSource {
+		return clout.CaptureTime | clout.CaptureCaller
+	}
+
+	return clout.CaptureTime
+}
+
+// Print converts a clout.Message to a slog.Record and passes it to the slog.Handler.
+// Highlighting is discarded, and any errors returned by the handler are ignored.
+//
+// If the message's time was not captured, the current time is used instead.
+func (p *Printer) Print(message clout.Message) {
+	ctx := context.Background()
+	level := messageToLevel(message)
+	if !p.handler.Enabled(ctx, level) {
+		return
+	}
+
+	when := message.Time()
+	if when.IsZero() {
+		when = time.Now()
+	}
+
+	record := slog.NewRecord(when, level, message.String(), 0)
+	if caller := message.Caller(); p.addSource && caller.File != "" {
+		record.AddAttrs(slog.Any(slog.SourceKey, &slog.Source{
+			Function: caller.Function,
+			File:     caller.File,
+			Line:     caller.Line,
+		}))
+	}
+
+	if message.Name() != "" {
+		record.AddAttrs(slog.String("name", message.Name()))
+	}
+
+	if message.Code() != "" {
+		record.AddAttrs(slog.String("code", message.Code()))
+	}
+
+	if message.Err() != nil {
+		record.AddAttrs(slog.Any("error", message.Err()))
+	}
+
+	for _, field := range message.Fields() {
+		record.AddAttrs(slog.Any(field.Key, field.Value))
+	}
+
+	_ = p.handler.Handle(ctx, record)
+}
+
+// messageToLevel converts a clout.Message's kind and verbosity to a slog.Level.
+//
+//   Error severity        -> LevelError
+//   Warning severity      -> LevelWarn
+//   Trace                 -> LevelDebug - 4
+//   Debug                 -> LevelDebug
+//   V(0) through V(2)     -> LevelInfo
+//   V(3) and V(4)         -> LevelDebug
+//   V(5) and above        -> LevelDebug - 4
+func messageToLevel(message clout.Message) slog.Level {
+	switch message.Kind().Severity() {
+	case clout.ErrorSeverity:
+		return slog.LevelError
+	case clout.WarningSeverity:
+		return slog.LevelWarn
+	}
+
+	switch message.Kind() {
+	case clout.Trace:
+		return slog.LevelDebug - 4
+	case clout.Debug:
+		return slog.LevelDebug
+	}
+
+	switch {
+	case message.Verbosity() <= 2:
+		return slog.LevelInfo
+	case message.Verbosity() <= 4:
+		return slog.LevelDebug
+	default:
+		return slog.LevelDebug - 4
+	}
+}
@@ -21,6 +21,10 @@ func (s Style) Apply(str string) string {
 		appendAnsiParameter(&sb, "1")
 	}
 
+	if s.dim {
+		appendAnsiParameter(&sb, "2")
+	}
+
 	if fg != "" {
 		appendAnsiParameter(&sb, "3"+fg)
 	}
@@ -42,6 +42,10 @@ func TestApply(t *testing.T) {
 			input:    Plain().Bold(true),
 			expected: "\x1B[1m" + "test" + ansiReset,
 		},
+		"Dim": {
+			input:    Plain().Dim(true),
+			expected: "\x1B[2m" + "test" + ansiReset,
+		},
 		"All": {
 			input:    Plain().Foreground(Green).Background(Red).Bold(true),
 			expected: "\x1B[1;32;41m" + "test" + ansiReset,
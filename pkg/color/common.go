@@ -19,6 +19,7 @@ type Style struct {
 	foreground Color
 	background Color
 	bold       bool
+	dim        bool
 }
 
 // Foreground creates a new Style with a foreground Color.
@@ -37,6 +38,7 @@ func Plain() Style {
 		foreground: None,
 		background: None,
 		bold:       false,
+		dim:        false,
 	}
 }
 
@@ -57,3 +59,9 @@ func (s Style) Bold(bold bool) Style {
 	s.bold = bold
 	return s
 }
+
+// Dim applies a dim (faint) attribute to the Style.
+func (s Style) Dim(dim bool) Style {
+	s.dim = dim
+	return s
+}
@@ -77,6 +77,14 @@ func TestFunctions(t *testing.T) {
 				bold:       true,
 			},
 		},
+		"Dim": {
+			got: Plain().Dim(true),
+			expected: Style{
+				foreground: None,
+				background: None,
+				dim:        true,
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,210 @@
+package klogcompat
+
+import (
+	"flag"
+	"strconv"
+
+	"go.eth-p.dev/clout"
+)
+
+// Level is the verbosity level of a message.
+// This implements flag.Value, and setting it will change the global clout verbosity.
+type Level int32
+
+// String returns the string representation of the Level.
+func (l *Level) String() string {
+	return strconv.FormatInt(int64(*l), 10)
+}
+
+// Get returns the Level.
+func (l *Level) Get() interface{} {
+	return *l
+}
+
+// Set parses a Level and sets it as the global clout verbosity.
+func (l *Level) Set(value string) error {
+	v, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	*l = Level(v)
+	clout.SetVerbosity(clout.MessageVerbosity(v))
+	return nil
+}
+
+var _ flag.Getter = (*Level)(nil)
+
+// Info prints an Info message, formatting the arguments like fmt.Print.
+func Info(args ...interface{}) {
+	logger(0).Infof(sprintFormat(args), args...)
+}
+
+// InfoDepth prints an Info message, formatting the arguments like fmt.Print.
+// The depth is the number of additional stack frames to skip when capturing the caller.
+func InfoDepth(depth int, args ...interface{}) {
+	logger(depth).Infof(sprintFormat(args), args...)
+}
+
+// Infoln prints an Info message, formatting the arguments like fmt.Println.
+func Infoln(args ...interface{}) {
+	logger(0).Infoln(args...)
+}
+
+// Infof prints a formatted Info message.
+func Infof(format string, args ...interface{}) {
+	logger(0).Infof(format, args...)
+}
+
+// InfoS prints an Info message with key/value pairs.
+func InfoS(msg string, keysAndValues ...interface{}) {
+	logger(0).WithValues(keysAndValues...).Infof("%s", msg)
+}
+
+// Warning prints a Warning message, formatting the arguments like fmt.Print.
+func Warning(args ...interface{}) {
+	logger(0).Warningf(sprintFormat(args), args...)
+}
+
+// WarningDepth prints a Warning message, formatting the arguments like fmt.Print.
+// The depth is the number of additional stack frames to skip when capturing the caller.
+func WarningDepth(depth int, args ...interface{}) {
+	logger(depth).Warningf(sprintFormat(args), args...)
+}
+
+// Warningln prints a Warning message, formatting the arguments like fmt.Println.
+func Warningln(args ...interface{}) {
+	logger(0).Warningln(args...)
+}
+
+// Warningf prints a formatted Warning message.
+func Warningf(format string, args ...interface{}) {
+	logger(0).Warningf(format, args...)
+}
+
+// Error prints an Error message, formatting the arguments like fmt.Print.
+func Error(args ...interface{}) {
+	logger(0).Errorf(sprintFormat(args), args...)
+}
+
+// ErrorDepth prints an Error message, formatting the arguments like fmt.Print.
+// The depth is the number of additional stack frames to skip when capturing the caller.
+func ErrorDepth(depth int, args ...interface{}) {
+	logger(depth).Errorf(sprintFormat(args), args...)
+}
+
+// Errorln prints an Error message, formatting the arguments like fmt.Println.
+func Errorln(args ...interface{}) {
+	logger(0).Errorln(args...)
+}
+
+// Errorf prints a formatted Error message.
+func Errorf(format string, args ...interface{}) {
+	logger(0).Errorf(format, args...)
+}
+
+// ErrorS prints an Error message with an error and key/value pairs.
+// The error is attached as the "err" field if it is not nil.
+func ErrorS(err error, msg string, keysAndValues ...interface{}) {
+	errorS(logger(1), err, msg, keysAndValues)
+}
+
+// Fatal prints an Error message, formatting the arguments like fmt.Print, then exits through clout.Exit with code 255.
+func Fatal(args ...interface{}) {
+	logger(0).Errorf(sprintFormat(args), args...)
+	clout.Exit(255)
+}
+
+// FatalDepth prints an Error message, formatting the arguments like fmt.Print, then exits through clout.Exit with code 255.
+// The depth is the number of additional stack frames to skip when capturing the caller.
+func FatalDepth(depth int, args ...interface{}) {
+	logger(depth).Errorf(sprintFormat(args), args...)
+	clout.Exit(255)
+}
+
+// Fatalln prints an Error message, formatting the arguments like fmt.Println, then exits through clout.Exit with code 255.
+func Fatalln(args ...interface{}) {
+	logger(0).Errorln(args...)
+	clout.Exit(255)
+}
+
+// Fatalf prints a formatted Error message, then exits through clout.Exit with code 255.
+func Fatalf(format string, args ...interface{}) {
+	logger(0).Errorf(format, args...)
+	clout.Exit(255)
+}
+
+// Exit prints an Error message, formatting the arguments like fmt.Print, then exits through clout.Exit with code 1.
+func Exit(args ...interface{}) {
+	logger(0).Errorf(sprintFormat(args), args...)
+	clout.Exit(1)
+}
+
+// ExitDepth prints an Error message, formatting the arguments like fmt.Print, then exits through clout.Exit with code 1.
+// The depth is the number of additional stack frames to skip when capturing the caller.
+func ExitDepth(depth int, args ...interface{}) {
+	logger(depth).Errorf(sprintFormat(args), args...)
+	clout.Exit(1)
+}
+
+// Exitln prints an Error message, formatting the arguments like fmt.Println, then exits through clout.Exit with code 1.
+func Exitln(args ...interface{}) {
+	logger(0).Errorln(args...)
+	clout.Exit(1)
+}
+
+// Exitf prints a formatted Error message, then exits through clout.Exit with code 1.
+func Exitf(format string, args ...interface{}) {
+	logger(0).Errorf(format, args...)
+	clout.Exit(1)
+}
+
+// Flush flushes any buffered messages in the global clout printer.
+func Flush() {
+	_ = clout.Flush()
+}
+
+// logger creates a clout.Verbose for printing V(0) messages from the functions in this package.
+// The depth is the number of additional stack frames between the caller and the function calling logger.
+func logger(depth int) *clout.Verbose {
+	return clout.V(0).WithCallDepth(depth + 1)
+}
+
+// errorS prints an Error message with an error and key/value pairs.
+func errorS(v *clout.Verbose, err error, msg string, keysAndValues []interface{}) {
+	v = v.WithValues(keysAndValues...)
+	if err != nil {
+		v = v.WithValues("err", err)
+	}
+
+	v.Errorf("%s", msg)
+}
+
+// sprintFormat generates a format string that formats arguments the same way as fmt.Sprint.
+// Spaces are added between operands when neither is a string.
+func sprintFormat(args []interface{}) string {
+	format := make([]byte, 0, len(args)*3)
+	for i, arg := range args {
+		if i > 0 && !isString(args[i-1]) && !isString(arg) {
+			format = append(format, ' ')
+		}
+
+		format = append(format, "%v"...)
+	}
+
+	return string(format)
+}
+
+// isString returns true if the value is a string.
+func isString(value interface{}) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+// InitFlags registers klog-compatible command-line flags in a flag.FlagSet.
+// If the flag.FlagSet is nil, flag.CommandLine will be used.
+//
+// See clout.RegisterFlags for the list of flags.
+func InitFlags(fs *flag.FlagSet) {
+	clout.RegisterFlags(fs)
+}
@@ -0,0 +1,181 @@
+package klogcompat
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.eth-p.dev/clout"
+)
+
+type testPrinter struct {
+	messages []clout.Message
+}
+
+func (p *testPrinter) Print(message clout.Message) {
+	p.messages = append(p.messages, message)
+}
+
+type testMessage struct {
+	Kind   clout.MessageKind
+	Text   string
+	Fields []clout.Field
+}
+
+func TestKlog(t *testing.T) {
+	testErr := errors.New("oops")
+	tests := map[string]struct {
+		expected []testMessage
+		exitCode int
+		fn       func()
+	}{
+		"Info": {
+			expected: []testMessage{{Kind: clout.Info, Text: "a1 2b"}},
+			fn:       func() { Info("a", 1, 2, "b") },
+		},
+		"Infoln": {
+			expected: []testMessage{{Kind: clout.Info, Text: "a 1 2 b"}},
+			fn:       func() { Infoln("a", 1, 2, "b") },
+		},
+		"InfoS": {
+			expected: []testMessage{{Kind: clout.Info, Text: "hello", Fields: []clout.Field{{Key: "a", Value: 1}}}},
+			fn:       func() { InfoS("hello", "a", 1) },
+		},
+		"Warningf": {
+			expected: []testMessage{{Kind: clout.Warning, Text: "hello world"}},
+			fn:       func() { Warningf("hello %s", "world") },
+		},
+		"ErrorS": {
+			expected: []testMessage{{Kind: clout.Error, Text: "failed", Fields: []clout.Field{{Key: "err", Value: testErr}}}},
+			fn:       func() { ErrorS(testErr, "failed") },
+		},
+		"ErrorS Nil": {
+			expected: []testMessage{{Kind: clout.Error, Text: "failed"}},
+			fn:       func() { ErrorS(nil, "failed") },
+		},
+		"Fatalf": {
+			expected: []testMessage{{Kind: clout.Error, Text: "fatal"}},
+			exitCode: 255,
+			fn:       func() { Fatalf("fatal") },
+		},
+		"Exitf": {
+			expected: []testMessage{{Kind: clout.Error, Text: "exit"}},
+			exitCode: 1,
+			fn:       func() { Exitf("exit") },
+		},
+		"V Enabled": {
+			expected: []testMessage{{Kind: clout.Info, Text: "visible"}},
+			fn:       func() { V(2).InfoS("visible") },
+		},
+		"V Disabled": {
+			expected: nil,
+			fn:       func() { V(5).InfoS("hidden") },
+		},
+	}
+
+	oldPrinter := clout.GetPrinter()
+	defer func() {
+		clout.SetPrinter(oldPrinter)
+		clout.SetExitFunc(nil)
+	}()
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &testPrinter{}
+			clout.SetPrinter(p)
+
+			exitCode := 0
+			clout.SetExitFunc(func(code int) { exitCode = code })
+
+			tc.fn()
+
+			var got []testMessage
+			for _, message := range p.messages {
+				got = append(got, testMessage{
+					Kind:   message.Kind(),
+					Text:   message.String(),
+					Fields: message.Fields(),
+				})
+			}
+
+			diff := cmp.Diff(tc.expected, got, cmp.Comparer(func(a, b error) bool { return a == b }))
+			if diff != "" {
+				t.Log("did not find expected Message; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+
+			if exitCode != tc.exitCode {
+				t.Fatalf("expected exit code %d, got %d", tc.exitCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestLevel(t *testing.T) {
+	oldVerbosity := clout.GetVerbosity()
+	defer clout.SetVerbosity(oldVerbosity)
+
+	var level Level
+	if err := level.Set("4"); err != nil {
+		t.Fatal(err)
+	}
+
+	if clout.GetVerbosity() != 4 {
+		t.Fatalf("expected verbosity 4, got %d", clout.GetVerbosity())
+	}
+
+	if err := level.Set("four"); err == nil {
+		t.Fatalf("expected error for invalid level")
+	}
+}
+
+type testCapturingPrinter struct {
+	testPrinter
+}
+
+func (p *testCapturingPrinter) Capture() clout.Capture {
+	return clout.CaptureCaller
+}
+
+func TestCaller(t *testing.T) {
+	tests := map[string]struct {
+		fn func()
+	}{
+		"Info":      {fn: func() { Info("hello") }},
+		"Infof":     {fn: func() { Infof("hello") }},
+		"ErrorS":    {fn: func() { ErrorS(nil, "hello") }},
+		"Fatalf":    {fn: func() { Fatalf("hello") }},
+		"V Info":    {fn: func() { V(0).Info("hello") }},
+		"V ErrorS":  {fn: func() { V(0).ErrorS(nil, "hello") }},
+		"InfoDepth": {fn: func() { func() { InfoDepth(1, "hello") }() }},
+		"V InfoDepth": {fn: func() {
+			func() { V(0).InfoDepth(1, "hello") }()
+		}},
+	}
+
+	oldPrinter := clout.GetPrinter()
+	defer func() {
+		clout.SetPrinter(oldPrinter)
+		clout.SetExitFunc(nil)
+	}()
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &testCapturingPrinter{}
+			clout.SetPrinter(p)
+			clout.SetExitFunc(func(code int) {})
+
+			tc.fn()
+
+			if len(p.messages) != 1 {
+				t.Fatalf("expected 1 message, got %d", len(p.messages))
+			}
+
+			caller := p.messages[0].Caller()
+			if filepath.Base(caller.File) != "klog_test.go" {
+				t.Fatalf("expected caller in klog_test.go, got %q (%s)", caller.File, caller.Function)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package klogcompat
+
+import (
+	"go.eth-p.dev/clout"
+)
+
+// Verbose is a klog-compatible wrapper around a clout.Verbose.
+// Messages printed through it will only be printed if the verbosity level is enabled.
+type Verbose struct {
+	v *clout.Verbose
+}
+
+// V creates a Verbose for printing messages at a verbosity level.
+//
+// Example:
+//
+//     klogcompat.V(2).InfoS("processing", "file", path)
+func V(level Level) Verbose {
+	return Verbose{v: clout.VDepth(1, clout.MessageVerbosity(level)).WithCallDepth(1)}
+}
+
+// Enabled returns true if messages at this verbosity level will be printed.
+func (v Verbose) Enabled() bool {
+	return v.v.Enabled()
+}
+
+// Info prints an Info message, formatting the arguments like fmt.Print.
+func (v Verbose) Info(args ...interface{}) {
+	if v.Enabled() {
+		v.v.Infof(sprintFormat(args), args...)
+	}
+}
+
+// InfoDepth prints an Info message, formatting the arguments like fmt.Print.
+// The depth is the number of additional stack frames to skip when capturing the caller.
+func (v Verbose) InfoDepth(depth int, args ...interface{}) {
+	if v.Enabled() {
+		v.v.WithCallDepth(depth).Infof(sprintFormat(args), args...)
+	}
+}
+
+// Infoln prints an Info message, formatting the arguments like fmt.Println.
+func (v Verbose) Infoln(args ...interface{}) {
+	v.v.Infoln(args...)
+}
+
+// Infof prints a formatted Info message.
+func (v Verbose) Infof(format string, args ...interface{}) {
+	v.v.Infof(format, args...)
+}
+
+// InfoS prints an Info message with key/value pairs.
+func (v Verbose) InfoS(msg string, keysAndValues ...interface{}) {
+	if v.Enabled() {
+		v.v.WithValues(keysAndValues...).Infof("%s", msg)
+	}
+}
+
+// Error prints an Error message, formatting the arguments like fmt.Print.
+func (v Verbose) Error(args ...interface{}) {
+	if v.Enabled() {
+		v.v.Errorf(sprintFormat(args), args...)
+	}
+}
+
+// ErrorS prints an Error message with an error and key/value pairs.
+// The error is attached as the "err" field if it is not nil.
+func (v Verbose) ErrorS(err error, msg string, keysAndValues ...interface{}) {
+	if v.Enabled() {
+		errorS(v.v.WithCallDepth(1), err, msg, keysAndValues)
+	}
+}
@@ -1,7 +1,7 @@
 package clout
 
 import (
-	"fmt"
+	"io"
 	"os"
 
 	"go.eth-p.dev/clout/pkg/color"
@@ -9,15 +9,25 @@ import (
 
 // PrinterInterface processes and prints a Message.
 // This can be used instead of Printer if more fine-grained control over messages are needed.
+//
+// Print should not panic if the message cannot be written. Instead, implementations should handle the error
+// themselves, like Printer does with its WriteErrorPolicy.
 type PrinterInterface interface {
 	Print(message Message)
 }
 
 // Printer is an implementation of PrinterInterface which prints to Output instances.
 // Each MessageKind can be configured to use different Output instances.
+//
+// If an Output fails to write a message, the error is handled according to the Printer's WriteErrorPolicy.
+// By default, the program exits with code 0 if the output is a closed pipe, and other errors are ignored.
 type Printer struct {
-	outputs  map[MessageKind]*Output
-	fallback *Output
+	outputs     map[MessageKind]*Output
+	fallback    *Output
+	writeErrors writeErrors
+
+	maxVerbosity    MessageVerbosity
+	hasMaxVerbosity bool
 }
 
 func (p *Printer) Print(message Message) {
@@ -27,25 +37,91 @@ func (p *Printer) Print(message Message) {
 		output = p.fallback
 	}
 
+	if !output.accepts(&message) {
+		return
+	}
+
 	// Write the message to the output.
 	err := output.write(&message)
 	if err != nil {
-		panic(fmt.Errorf("failed to print message; err= %w", err))
+		p.writeErrors.handle(err, message, func(stderr io.Writer) error {
+			if output.writer == stderr {
+				return err
+			}
+
+			return output.withWriter(stderr).write(&message)
+		})
 	}
 }
 
+// Err returns the last error that occurred while writing a message.
+func (p *Printer) Err() error {
+	return p.writeErrors.lastError()
+}
+
+// SetWriteErrorPolicy changes what the Printer does when it fails to write a message.
+func (p *Printer) SetWriteErrorPolicy(policy WriteErrorPolicy) *Printer {
+	p.writeErrors.setPolicy(policy)
+	return p
+}
+
+// SetWriteErrorHandler sets a function to call when the Printer fails to write a message.
+// This changes the WriteErrorPolicy to CallWriteErrorHandler.
+func (p *Printer) SetWriteErrorHandler(handler WriteErrorHandler) *Printer {
+	p.writeErrors.setHandler(handler)
+	return p
+}
+
+// Capture returns the information that needs to be captured for messages printed by the Printer.
+// This is determined by the timestamp and caller options of the Printer's Output instances.
+func (p *Printer) Capture() Capture {
+	var capture Capture
+	if p.fallback != nil {
+		capture = p.fallback.capture()
+	}
+
+	for _, output := range p.outputs {
+		capture |= output.capture()
+	}
+
+	return capture
+}
+
+// MaxVerbosity returns the highest max verbosity of the Printer's Output instances.
+// If none of the Output instances have a max verbosity, this returns false.
+func (p *Printer) MaxVerbosity() (MessageVerbosity, bool) {
+	return p.maxVerbosity, p.hasMaxVerbosity
+}
+
 // SetOutput changes the default Output for all messages that are not handled by SetOutputForKind.
 func (p *Printer) SetOutput(output Output) *Printer {
 	p.fallback = &output
+	p.updateMaxVerbosity()
 	return p
 }
 
 // SetOutputForKind changes the Output for all messages of a MessageKind.
 func (p *Printer) SetOutputForKind(kind MessageKind, output Output) *Printer {
 	p.outputs[kind] = &output
+	p.updateMaxVerbosity()
 	return p
 }
 
+// updateMaxVerbosity updates the cached result of MaxVerbosity after an Output is changed.
+func (p *Printer) updateMaxVerbosity() {
+	p.maxVerbosity, p.hasMaxVerbosity = 0, false
+	update := func(output *Output) {
+		if output != nil && output.hasMaxVerbosity && (!p.hasMaxVerbosity || output.maxVerbosity > p.maxVerbosity) {
+			p.maxVerbosity, p.hasMaxVerbosity = output.maxVerbosity, true
+		}
+	}
+
+	update(p.fallback)
+	for _, output := range p.outputs {
+		update(output)
+	}
+}
+
 // NewPrinter creates a Printer with default settings.
 // It will print all messages to stdout.
 func NewPrinter() *Printer {
@@ -59,18 +135,31 @@ func NewPrinter() *Printer {
 // It will print warnings and errors to stderr, and other messages to stdout.
 // If stdout/stderr is a terminal, it will apply color output to those messages as well.
 func NewPrinterWithDefaults(colors bool) *Printer {
-	stderr := OutputFromFile(os.Stderr)
-
-	return NewPrinter().
-		SetOutputForKind(Warning, stderr.
-			WithColor(optionallyColored(colors, color.Foreground(color.Yellow))).
-			WithPrefix("warning:", optionallyColored(colors, color.Foreground(color.Yellow).Bold(true)))).
-		SetOutputForKind(Deprecation, stderr.
-			WithColor(optionallyColored(colors, color.Foreground(color.Yellow))).
-			WithPrefix("deprecated:", optionallyColored(colors, color.Foreground(color.Yellow).Bold(true)))).
-		SetOutputForKind(Error, stderr.
-			WithColor(optionallyColored(colors, color.Foreground(color.Red))).
-			WithPrefix("error:", optionallyColored(colors, color.Foreground(color.Red).Bold(true))))
+	return newPrinterWithDefaults(OutputFromFile(os.Stdout), OutputFromFile(os.Stderr), colors)
+}
+
+// newPrinterWithDefaults creates a Printer with default settings for stdout and stderr Output instances.
+// Each registered MessageKind is given an Output based on its KindOutput.
+func newPrinterWithDefaults(stdout Output, stderr Output, colors bool) *Printer {
+	printer := (&Printer{outputs: make(map[MessageKind]*Output)}).
+		SetOutput(stdout)
+
+	for kind, info := range registeredKinds() {
+		if info.output == (KindOutput{}) {
+			continue
+		}
+
+		output := stdout
+		if info.output.Stderr {
+			output = stderr
+		}
+
+		printer.SetOutputForKind(MessageKind(kind), output.
+			WithColor(optionallyColored(colors, info.output.Color)).
+			WithPrefix(info.output.Prefix, optionallyColored(colors, info.output.PrefixColor)))
+	}
+
+	return printer
 }
 
 func optionallyColored(enabled bool, c color.Style) color.Style {
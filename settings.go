@@ -0,0 +1,189 @@
+package clout
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ColorMode controls when colors are used by the default printer.
+type ColorMode int
+
+const (
+	// ColorAuto enables colors when the output is a terminal that supports them.
+	ColorAuto ColorMode = iota
+
+	// ColorAlways enables colors, even if the output is not a terminal.
+	ColorAlways ColorMode = iota
+
+	// ColorNever disables colors.
+	ColorNever ColorMode = iota
+)
+
+// String returns the name of the ColorMode.
+func (m ColorMode) String() string {
+	switch m {
+	case ColorAlways:
+		return "always"
+	case ColorNever:
+		return "never"
+	default:
+		return "auto"
+	}
+}
+
+// ParseColorMode parses the name of a ColorMode.
+// This accepts "auto", "always", and "never".
+func ParseColorMode(name string) (ColorMode, error) {
+	switch strings.ToLower(name) {
+	case "auto":
+		return ColorAuto, nil
+	case "always":
+		return ColorAlways, nil
+	case "never":
+		return ColorNever, nil
+	default:
+		return ColorAuto, fmt.Errorf("unknown color mode %q; expected auto, always, or never", name)
+	}
+}
+
+// OutputFormat controls how the default printer formats messages.
+type OutputFormat int
+
+const (
+	// TextFormat prints messages as human-readable text.
+	TextFormat OutputFormat = iota
+
+	// JSONFormat prints messages as JSON objects, one per line.
+	JSONFormat OutputFormat = iota
+)
+
+// String returns the name of the OutputFormat.
+func (f OutputFormat) String() string {
+	switch f {
+	case JSONFormat:
+		return "json"
+	default:
+		return "text"
+	}
+}
+
+// ParseOutputFormat parses the name of an OutputFormat.
+// This accepts "text" and "json".
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	switch strings.ToLower(name) {
+	case "text":
+		return TextFormat, nil
+	case "json":
+		return JSONFormat, nil
+	default:
+		return TextFormat, fmt.Errorf("unknown output format %q; expected text or json", name)
+	}
+}
+
+// settings are the options used to create the default global printer.
+type settings struct {
+	color    ColorMode
+	format   OutputFormat
+	quiet    bool
+	toStderr bool
+}
+
+var globalSettingsMutex sync.Mutex
+var globalSettings settings
+var globalPrinterIsDefault bool
+
+// updateSettings changes the settings used to create the default global printer.
+// If the global printer has not been replaced with SetPrinter, it will be recreated with the new settings.
+func updateSettings(update func(s *settings)) {
+	globalSettingsMutex.Lock()
+	defer globalSettingsMutex.Unlock()
+
+	update(&globalSettings)
+	if globalPrinterIsDefault {
+		setPrinter(newDefaultPrinter(globalSettings))
+	}
+}
+
+// resetDefaultPrinter replaces the global printer with one created from the current settings.
+func resetDefaultPrinter() {
+	globalSettingsMutex.Lock()
+	defer globalSettingsMutex.Unlock()
+
+	globalPrinterIsDefault = true
+	setPrinter(newDefaultPrinter(globalSettings))
+}
+
+// newDefaultPrinter creates a PrinterInterface from settings.
+func newDefaultPrinter(s settings) PrinterInterface {
+	stdout := os.Stdout
+	if s.toStderr {
+		stdout = os.Stderr
+	}
+
+	var printer PrinterInterface
+	switch s.format {
+	case JSONFormat:
+		printer = NewJSONPrinter(stdout)
+	default:
+		colors := s.color != ColorNever
+		printer = newPrinterWithDefaults(
+			OutputFromFile(stdout).WithColors(colorsEnabled(s.color, stdout)),
+			OutputFromFile(os.Stderr).WithColors(colorsEnabled(s.color, os.Stderr)),
+			colors,
+		)
+	}
+
+	if s.quiet {
+		printer = quietPrinter{printer}
+	}
+
+	return printer
+}
+
+// colorsEnabled checks if colors should be enabled for an os.File with a ColorMode.
+func colorsEnabled(mode ColorMode, file *os.File) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return supportsColor(file)
+	}
+}
+
+// quietPrinter is a PrinterInterface that discards messages below WarningSeverity.
+type quietPrinter struct {
+	printer PrinterInterface
+}
+
+func (p quietPrinter) Print(message Message) {
+	if message.aboveVerbosity {
+		p.printer.Print(message)
+		return
+	}
+
+	if message.Kind().Severity() < WarningSeverity {
+		return
+	}
+
+	p.printer.Print(message)
+}
+
+// Capture returns the information that the wrapped PrinterInterface needs captured.
+func (p quietPrinter) Capture() Capture {
+	return captureOf(p.printer)
+}
+
+// MaxVerbosity returns the highest MessageVerbosity that the wrapped PrinterInterface wants to receive.
+func (p quietPrinter) MaxVerbosity() (MessageVerbosity, bool) {
+	return maxVerbosityOf(p.printer)
+}
+
+// Flush flushes the wrapped PrinterInterface.
+func (p quietPrinter) Flush(ctx context.Context) error {
+	return FlushPrinter(ctx, p.printer)
+}
@@ -1,7 +1,9 @@
 package clout
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -16,23 +18,104 @@ func argsToFormat(args []interface{}) string {
 	return argsFmt
 }
 
+// appendFields appends alternating keys and values to a copy of a Field slice.
+// Non-string keys are converted to strings, and a key without a value will be given the value "(MISSING)".
+func appendFields(fields []Field, keysAndValues []interface{}) []Field {
+	if len(keysAndValues) == 0 {
+		return fields
+	}
+
+	merged := make([]Field, len(fields), len(fields)+(len(keysAndValues)+1)/2)
+	copy(merged, fields)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+
+		var value interface{} = "(MISSING)"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+
+		merged = append(merged, Field{Key: key, Value: value})
+	}
+
+	return merged
+}
+
 // supportsColor checks if an os.File (e.g. stdout) supports colors.
 //
-// This is based on the following rules:
+// This is based on the following rules, in order:
+// - If $FORCE_COLOR is "0" or "false", disable colors.
+// - If $FORCE_COLOR is defined, enable colors.
+// - If $CLICOLOR_FORCE is defined and not "0", enable colors.
 // - If $NO_COLOR is defined, disable colors.
+// - If $CLICOLOR is "0", disable colors.
+// - If $TERM is "dumb", disable colors.
 // - If the os.File is not a terminal, disable colors.
 // - Otherwise, enable colors.
 func supportsColor(fd *os.File) bool {
+	return envSupportsColor(os.LookupEnv, isTerminal(fd))
+}
+
+// envSupportsColor checks if colors should be enabled based on environment variables.
+// See supportsColor for the rules.
+func envSupportsColor(lookupEnv func(key string) (string, bool), terminal bool) bool {
+	// FORCE_COLOR and CLICOLOR_FORCE take priority over everything else.
+	if value, exists := lookupEnv("FORCE_COLOR"); exists {
+		return value != "0" && !strings.EqualFold(value, "false")
+	}
+
+	if value, exists := lookupEnv("CLICOLOR_FORCE"); exists && value != "" && value != "0" {
+		return true
+	}
+
 	// If NO_COLOR is defined, we should not be printing color.
-	if _, exists := os.LookupEnv("NO_COLOR"); exists {
+	if _, exists := lookupEnv("NO_COLOR"); exists {
 		return false
 	}
 
-	// If the output FD is not a terminal, we should not be printing color.
-	if stat, _ := fd.Stat(); (stat.Mode() & os.ModeCharDevice) == 0 {
+	if value, _ := lookupEnv("CLICOLOR"); value == "0" {
 		return false
 	}
 
-	// It's fine to print color.
-	return true
+	// If the terminal is dumb, it probably doesn't support ANSI escape codes.
+	if value, _ := lookupEnv("TERM"); value == "dumb" {
+		return false
+	}
+
+	// If the output FD is not a terminal, we should not be printing color.
+	return terminal
+}
+
+// isTerminal checks if an os.File is a terminal.
+func isTerminal(fd *os.File) bool {
+	stat, err := fd.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
+// formatCount formats a number with comma thousands separators (e.g. "4,999").
+func formatCount(n int) string {
+	digits := strconv.Itoa(n)
+	start := 0
+	if n < 0 {
+		start = 1
+	}
+
+	for i := len(digits) - 3; i > start; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return digits
+}
+
+// pluralize formats a count with the singular or plural form of a word (e.g. "1 error", "2 errors").
+func pluralize(count int, singular string, plural string) string {
+	if count == 1 {
+		return "1 " + singular
+	}
+
+	return formatCount(count) + " " + plural
 }
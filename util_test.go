@@ -2,6 +2,8 @@ package clout
 
 import (
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestArgsToFormat(t *testing.T) {
@@ -32,3 +34,62 @@ func TestArgsToFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendFields(t *testing.T) {
+	tests := map[string]struct {
+		fields        []Field
+		keysAndValues []interface{}
+		expected      []Field
+	}{
+		"Empty": {
+			keysAndValues: []interface{}{},
+			expected:      nil,
+		},
+		"Pairs": {
+			keysAndValues: []interface{}{"a", 1, "b", 2},
+			expected:      []Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+		},
+		"Existing": {
+			fields:        []Field{{Key: "a", Value: 1}},
+			keysAndValues: []interface{}{"b", 2},
+			expected:      []Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+		},
+		"Missing Value": {
+			keysAndValues: []interface{}{"a"},
+			expected:      []Field{{Key: "a", Value: "(MISSING)"}},
+		},
+		"Non-String Key": {
+			keysAndValues: []interface{}{1, "one"},
+			expected:      []Field{{Key: "1", Value: "one"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := appendFields(tc.fields, tc.keysAndValues)
+			diff := cmp.Diff(tc.expected, got)
+			if diff != "" {
+				t.Log("did not find expected fields; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := map[int]string{
+		0:        "0",
+		999:      "999",
+		1000:     "1,000",
+		4999:     "4,999",
+		1234567:  "1,234,567",
+		-1234567: "-1,234,567",
+		-100:     "-100",
+	}
+
+	for n, expected := range tests {
+		if got := formatCount(n); got != expected {
+			t.Fatalf("did not find expected formatting for %d; want '%s', got '%s'", n, expected, got)
+		}
+	}
+}
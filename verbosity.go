@@ -0,0 +1,54 @@
+package clout
+
+// VerbosityPrinter is an optional interface for PrinterInterface implementations that want messages that are more
+// verbose than the verbosity setting.
+//
+// When a Verbose is created for a MessageVerbosity above the verbosity setting, it is still enabled if the printer's
+// MaxVerbosity is at least that verbosity. This allows a single printer output (e.g. a debug log file) to receive
+// V(5) messages while everything else only receives the messages allowed by the verbosity setting.
+type VerbosityPrinter interface {
+	PrinterInterface
+
+	// MaxVerbosity returns the highest MessageVerbosity that the printer wants to receive.
+	// If the printer does not want messages above the verbosity setting, this returns false.
+	MaxVerbosity() (MessageVerbosity, bool)
+}
+
+// maxVerbosityOf returns the highest MessageVerbosity that a PrinterInterface wants to receive.
+// Nil printers and printers that do not implement VerbosityPrinter return false.
+func maxVerbosityOf(printer PrinterInterface) (MessageVerbosity, bool) {
+	if verbosityPrinter, ok := printer.(VerbosityPrinter); ok {
+		return verbosityPrinter.MaxVerbosity()
+	}
+
+	return 0, false
+}
+
+// maxVerbosityOfAll returns the highest MessageVerbosity that any of the printers want to receive.
+func maxVerbosityOfAll(printers ...PrinterInterface) (MessageVerbosity, bool) {
+	var max MessageVerbosity
+	var found bool
+	for _, printer := range printers {
+		if verbosity, ok := maxVerbosityOf(printer); ok && (!found || verbosity > max) {
+			max = verbosity
+			found = true
+		}
+	}
+
+	return max, found
+}
+
+// wantsMessage checks if a PrinterInterface should receive a Message.
+// Messages above the verbosity setting are only sent to printers whose MaxVerbosity allows them.
+func wantsMessage(printer PrinterInterface, message *Message) bool {
+	if printer == nil {
+		return false
+	}
+
+	if !message.aboveVerbosity {
+		return true
+	}
+
+	max, ok := maxVerbosityOf(printer)
+	return ok && message.verbosity <= max
+}
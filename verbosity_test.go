@@ -0,0 +1,333 @@
+package clout
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOutputThresholds(t *testing.T) {
+	tests := map[string]struct {
+		expected string
+		init     func(output Output) Output
+	}{
+		"Default": {
+			expected: "warning V(0)\ninfo V(3)\ndebug V(5)\n",
+			init:     func(output Output) Output { return output },
+		},
+		"Max Verbosity": {
+			expected: "warning V(0)\ninfo V(3)\n",
+			init: func(output Output) Output {
+				return output.WithMaxVerbosity(3)
+			},
+		},
+		"Min Severity": {
+			expected: "warning V(0)\n",
+			init: func(output Output) Output {
+				return output.WithMinSeverity(WarningSeverity)
+			},
+		},
+		"Survives Other Options": {
+			expected: "warning V(0)\n",
+			init: func(output Output) Output {
+				return output.WithMinSeverity(WarningSeverity).WithMaxVerbosity(3).WithColors(false)
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			p := NewPrinter().SetOutput(tc.init(OutputFromWriter(&buffer)))
+			p.Print(New(Warning, 0, "warning V(0)"))
+			p.Print(New(Info, 3, "info V(3)"))
+			p.Print(New(Debug, 5, "debug V(5)"))
+
+			diff := cmp.Diff(tc.expected, buffer.String())
+			if diff != "" {
+				t.Log("did not find expected output; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestPrinterMaxVerbosity(t *testing.T) {
+	p := NewPrinter()
+	if _, ok := p.MaxVerbosity(); ok {
+		t.Fatalf("expected printer without max verbosity")
+	}
+
+	p.SetOutput(OutputFromWriter(&bytes.Buffer{}).WithMaxVerbosity(3))
+	p.SetOutputForKind(Debug, OutputFromWriter(&bytes.Buffer{}).WithMaxVerbosity(5))
+	if max, ok := p.MaxVerbosity(); !ok || max != 5 {
+		t.Fatalf("expected max verbosity 5, got %d (%v)", max, ok)
+	}
+
+	p.SetOutputForKind(Debug, OutputFromWriter(&bytes.Buffer{}))
+	if max, ok := p.MaxVerbosity(); !ok || max != 3 {
+		t.Fatalf("expected max verbosity 3, got %d (%v)", max, ok)
+	}
+}
+
+func TestVerbosityPrinter(t *testing.T) {
+	tests := map[string]struct {
+		expectedTerminal []string
+		expectedLog      string
+		printer          func(terminal PrinterInterface, log PrinterInterface) PrinterInterface
+	}{
+		"Without Max Verbosity": {
+			expectedTerminal: []string{"V(0)", "V(2)"},
+			printer: func(terminal PrinterInterface, log PrinterInterface) PrinterInterface {
+				return terminal
+			},
+		},
+		"Tee": {
+			expectedTerminal: []string{"V(0)", "V(2)"},
+			expectedLog:      "V(0)\nV(2)\nV(3)\nV(5)\n",
+			printer: func(terminal PrinterInterface, log PrinterInterface) PrinterInterface {
+				return Tee(terminal, log)
+			},
+		},
+		"Wrapped": {
+			expectedTerminal: []string{"V(0)", "V(2)"},
+			expectedLog:      "V(0)\nV(2)\nV(3)\nV(5)\n",
+			printer: func(terminal PrinterInterface, log PrinterInterface) PrinterInterface {
+				return NewDedupPrinter(Tee(Filter(func(Message) bool { return true }, terminal), Map(func(m Message) Message { return m }, log)))
+			},
+		},
+		"RouteByKind": {
+			expectedTerminal: []string{"V(0)", "V(2)"},
+			expectedLog:      "V(3)\nV(5)\n",
+			printer: func(terminal PrinterInterface, log PrinterInterface) PrinterInterface {
+				return RouteByKind(map[MessageKind]PrinterInterface{Debug: log}, terminal)
+			},
+		},
+		"RouteByVerbosity": {
+			expectedTerminal: []string{"V(0)", "V(2)"},
+			expectedLog:      "V(5)\n",
+			printer: func(terminal PrinterInterface, log PrinterInterface) PrinterInterface {
+				return RouteByVerbosity(3, terminal, log)
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetGlobals(t)
+			SetVerbosity(2)
+
+			buffer := bytes.Buffer{}
+			terminal := &testPrinter{}
+			log := NewPrinter().SetOutput(OutputFromWriter(&buffer).WithMaxVerbosity(5))
+			SetPrinter(tc.printer(terminal, log))
+
+			V(0).Warningf("V(0)")
+			V(2).Infof("V(2)")
+			V(3).Debugf("V(3)")
+			V(5).Debugf("V(5)")
+			V(6).Debugf("V(6)")
+
+			var gotTerminal []string
+			for _, message := range terminal.messages {
+				gotTerminal = append(gotTerminal, message.String())
+			}
+
+			diff := cmp.Diff(tc.expectedTerminal, gotTerminal)
+			if diff != "" {
+				t.Log("did not find expected terminal messages; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(tc.expectedLog, buffer.String())
+			if diff != "" {
+				t.Log("did not find expected log output; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestVerbosityPrinterOutputs(t *testing.T) {
+	resetGlobals(t)
+	SetVerbosity(2)
+
+	terminal := bytes.Buffer{}
+	debugLog := bytes.Buffer{}
+	SetPrinter(NewPrinter().
+		SetOutput(OutputFromWriter(&terminal)).
+		SetOutputForKind(Debug, OutputFromWriter(&debugLog).WithMaxVerbosity(5)))
+
+	if !V(5).Enabled() || V(6).Enabled() {
+		t.Fatalf("expected V(5) to be enabled for the debug output only")
+	}
+
+	V(2).Infof("shown")
+	V(5).Infof("hidden")
+	V(5).Debugf("debug")
+
+	if terminal.String() != "shown\n" || debugLog.String() != "debug\n" {
+		t.Fatalf("unexpected output: terminal=%q debug=%q", terminal.String(), debugLog.String())
+	}
+}
+
+func TestVerbosePrintMessage(t *testing.T) {
+	resetGlobals(t)
+	SetVerbosity(2)
+
+	buffer := bytes.Buffer{}
+	terminal := &testPrinter{}
+	SetPrinter(Tee(terminal, NewPrinter().SetOutput(OutputFromWriter(&buffer).WithMaxVerbosity(5))))
+
+	V(2).PrintMessage(New(Info, 0, "V(2)"))
+	V(5).PrintMessage(New(Debug, 0, "V(5)"))
+	V(6).PrintMessage(New(Debug, 0, "V(6)"))
+
+	var gotTerminal []string
+	for _, message := range terminal.messages {
+		gotTerminal = append(gotTerminal, fmt.Sprintf("%s V(%d)", message.String(), message.Verbosity()))
+	}
+
+	diff := cmp.Diff([]string{"V(2) V(2)"}, gotTerminal)
+	if diff != "" {
+		t.Log("did not find expected terminal messages; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff("V(2)\nV(5)\n", buffer.String())
+	if diff != "" {
+		t.Log("did not find expected log output; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
+
+func TestVerboseWithPrinter(t *testing.T) {
+	resetGlobals(t)
+	SetVerbosity(2)
+
+	terminal := &testPrinter{}
+	buffer := bytes.Buffer{}
+	log := NewPrinter().SetOutput(OutputFromWriter(&buffer).WithMaxVerbosity(5))
+
+	SetPrinter(log)
+	V(2).WithPrinter(terminal).Infof("V(2)")
+	V(5).WithPrinter(terminal).Infof("V(5) terminal")
+
+	SetPrinter(terminal)
+	V(5).WithPrinter(log).Infof("V(5) log")
+	V(6).WithPrinter(log).Infof("V(6) log")
+
+	var gotTerminal []string
+	for _, message := range terminal.messages {
+		gotTerminal = append(gotTerminal, message.String())
+	}
+
+	diff := cmp.Diff([]string{"V(2)"}, gotTerminal)
+	if diff != "" {
+		t.Log("did not find expected terminal messages; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff("V(5) log\n", buffer.String())
+	if diff != "" {
+		t.Log("did not find expected log output; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
+
+func TestVerbosityPrinterWrappers(t *testing.T) {
+	tests := map[string]struct {
+		expectedTerminal []string
+		expectedLog      string
+		wrap             func(p PrinterInterface) PrinterInterface
+		fn               func(t *testing.T, p PrinterInterface)
+	}{
+		"CountingPrinter": {
+			expectedTerminal: []string{"shown"},
+			expectedLog:      "warning: hidden\nwarning: shown\n",
+			wrap: func(p PrinterInterface) PrinterInterface {
+				return NewCountingPrinter(p)
+			},
+			fn: func(t *testing.T, p PrinterInterface) {
+				V(5).Warningf("hidden")
+				V(0).Warningf("shown")
+				if count := p.(*CountingPrinter).Count(Warning); count != 1 {
+					t.Errorf("expected 1 warning to be counted, got %d", count)
+				}
+			},
+		},
+		"DedupPrinter": {
+			expectedTerminal: []string{"a", "(previous message repeated 1 time)"},
+			expectedLog:      "warning: a\nwarning: b\nwarning: (previous message repeated 1 time)\n",
+			wrap: func(p PrinterInterface) PrinterInterface {
+				return NewDedupPrinter(p)
+			},
+			fn: func(t *testing.T, p PrinterInterface) {
+				V(0).Warningf("a")
+				V(5).Warningf("b")
+				V(0).Warningf("a")
+				_ = Flush()
+			},
+		},
+		"Once": {
+			expectedTerminal: []string{"shown"},
+			expectedLog:      "warning: hidden\nwarning: shown\n",
+			wrap: func(p PrinterInterface) PrinterInterface {
+				return p
+			},
+			fn: func(t *testing.T, p PrinterInterface) {
+				ResetOnce()
+				t.Cleanup(ResetOnce)
+
+				V(5).Once("TestVerbosityPrinterWrappers").Warningf("hidden")
+				V(0).Once("TestVerbosityPrinterWrappers").Warningf("shown")
+			},
+		},
+		"Quiet": {
+			expectedTerminal: []string{"shown"},
+			expectedLog:      "hidden\nwarning: shown\n",
+			wrap: func(p PrinterInterface) PrinterInterface {
+				return quietPrinter{p}
+			},
+			fn: func(t *testing.T, p PrinterInterface) {
+				V(5).Debugf("hidden")
+				V(0).Infof("quiet")
+				V(0).Warningf("shown")
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetGlobals(t)
+			SetVerbosity(2)
+
+			buffer := bytes.Buffer{}
+			terminal := &testPrinter{}
+			log := newPrinterWithDefaults(OutputFromWriter(&buffer).WithMaxVerbosity(5), OutputFromWriter(&buffer).WithMaxVerbosity(5), false)
+			p := tc.wrap(Tee(terminal, log))
+			SetPrinter(p)
+
+			tc.fn(t, p)
+
+			var gotTerminal []string
+			for _, message := range terminal.messages {
+				gotTerminal = append(gotTerminal, message.String())
+			}
+
+			diff := cmp.Diff(tc.expectedTerminal, gotTerminal)
+			if diff != "" {
+				t.Log("did not find expected terminal messages; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+
+			diff = cmp.Diff(tc.expectedLog, buffer.String())
+			if diff != "" {
+				t.Log("did not find expected log output; want -> -, got -> +")
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package clout
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// vmodulePattern is a single "pattern=N" entry of a vmodule specification.
+type vmodulePattern struct {
+	pattern   string
+	verbosity MessageVerbosity
+}
+
+// vmoduleState is the parsed vmodule specification and its per-call-site cache.
+type vmoduleState struct {
+	spec     string
+	patterns []vmodulePattern
+	cache    sync.Map // map[uintptr]MessageVerbosity
+}
+
+// noVModule is the cached verbosity for call sites that do not match any vmodule pattern.
+const noVModule MessageVerbosity = -1
+
+var globalVModuleActive int32
+var globalVModuleMutex sync.RWMutex
+var globalVModule = &vmoduleState{}
+
+// GetVModule gets the vmodule specification used for per-file and per-package verbosity overrides.
+func GetVModule() string {
+	globalVModuleMutex.RLock()
+	defer globalVModuleMutex.RUnlock()
+	return globalVModule.spec
+}
+
+// SetVModule sets the vmodule specification used for per-file and per-package verbosity overrides.
+//
+// The specification is a comma-separated list of "pattern=N" entries, where N is the verbosity that will be used
+// for messages created in matching call sites. Patterns are shell globs that are matched against:
+//
+//   - The name of the source file, without the ".go" extension (e.g. "cache*").
+//   - The import path of the package (e.g. "net/http").
+//   - The last element of the package import path (e.g. "http").
+//
+// Patterns containing a "/" are also matched against the trailing directories of the source file.
+// The first matching pattern is used. The vmodule verbosity will only increase the verbosity of matching call sites.
+//
+// Example:
+//
+//     clout.SetVModule("cache*=5,net/http=3")
+func SetVModule(spec string) error {
+	var patterns []vmodulePattern
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		eq := strings.LastIndex(entry, "=")
+		if eq <= 0 {
+			return fmt.Errorf("invalid vmodule entry: %q", entry)
+		}
+
+		pattern := entry[:eq]
+		verbosity, err := strconv.Atoi(entry[eq+1:])
+		if err != nil {
+			return fmt.Errorf("invalid vmodule verbosity for %q: %w", pattern, err)
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid vmodule pattern %q: %w", pattern, err)
+		}
+
+		patterns = append(patterns, vmodulePattern{
+			pattern:   pattern,
+			verbosity: MessageVerbosity(verbosity),
+		})
+	}
+
+	globalVModuleMutex.Lock()
+	globalVModule = &vmoduleState{
+		spec:     spec,
+		patterns: patterns,
+	}
+
+	if len(patterns) > 0 {
+		atomic.StoreInt32(&globalVModuleActive, 1)
+	} else {
+		atomic.StoreInt32(&globalVModuleActive, 0)
+	}
+	globalVModuleMutex.Unlock()
+	return nil
+}
+
+// vmoduleEnabled returns true if a vmodule pattern enables the verbosity for a call site.
+// The skip parameter is the number of stack frames to skip, where 0 is the caller of vmoduleEnabled.
+func vmoduleEnabled(skip int, verbosity MessageVerbosity) bool {
+	// Fast path: there are no vmodule patterns.
+	if atomic.LoadInt32(&globalVModuleActive) == 0 {
+		return false
+	}
+
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return false
+	}
+
+	globalVModuleMutex.RLock()
+	state := globalVModule
+	globalVModuleMutex.RUnlock()
+
+	// Use the cached result for the call site, if there is one.
+	if cached, ok := state.cache.Load(pcs[0]); ok {
+		return verbosity <= cached.(MessageVerbosity)
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	matched := state.match(frame.File, frame.Function)
+	state.cache.Store(pcs[0], matched)
+	return verbosity <= matched
+}
+
+// match returns the verbosity of the first pattern matching a source file or function.
+// If no patterns match, noVModule is returned.
+func (s *vmoduleState) match(file string, function string) MessageVerbosity {
+	file = strings.TrimSuffix(filepath.ToSlash(file), ".go")
+	pkg := functionPackage(function)
+	candidates := []string{
+		file[strings.LastIndex(file, "/")+1:],
+		pkg,
+		pkg[strings.LastIndex(pkg, "/")+1:],
+	}
+
+	for _, p := range s.patterns {
+		if strings.Contains(p.pattern, "/") {
+			if ok, _ := path.Match(p.pattern, trailingPath(file, strings.Count(p.pattern, "/")+1)); ok {
+				return p.verbosity
+			}
+		}
+
+		for _, candidate := range candidates {
+			if ok, _ := path.Match(p.pattern, candidate); ok && candidate != "" {
+				return p.verbosity
+			}
+		}
+	}
+
+	return noVModule
+}
+
+// functionPackage returns the package import path of a fully-qualified function name.
+//
+// Example:
+//
+//     functionPackage("net/http.(*Server).Serve") // -> "net/http"
+func functionPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot == -1 {
+		return function
+	}
+
+	return function[:slash+1+dot]
+}
+
+// trailingPath returns the last n elements of a slash-separated file path.
+func trailingPath(file string, n int) string {
+	index := len(file)
+	for ; n > 0; n-- {
+		index = strings.LastIndex(file[:index], "/")
+		if index == -1 {
+			return file
+		}
+	}
+
+	return file[index+1:]
+}
@@ -0,0 +1,84 @@
+package clout
+
+import (
+	"testing"
+)
+
+func TestSetVModule(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		invalid bool
+	}{
+		"Empty":             {spec: ""},
+		"Single":            {spec: "cache*=5"},
+		"Multiple":          {spec: "cache*=5, net/http=3"},
+		"Missing Verbosity": {spec: "cache*", invalid: true},
+		"Invalid Verbosity": {spec: "cache*=x", invalid: true},
+		"Missing Pattern":   {spec: "=5", invalid: true},
+		"Invalid Pattern":   {spec: "cache[=5", invalid: true},
+	}
+
+	defer SetVModule("")
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := SetVModule(tc.spec)
+			if tc.invalid && err == nil {
+				t.Fatalf("expected error for %q", tc.spec)
+			} else if !tc.invalid && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestVModuleEnabled(t *testing.T) {
+	tests := map[string]struct {
+		spec     string
+		expected bool
+	}{
+		"No VModule":       {spec: "", expected: false},
+		"File Match":       {spec: "vmodule_test=5", expected: true},
+		"File Glob Match":  {spec: "vmodule*=5", expected: true},
+		"File Low Level":   {spec: "vmodule_test=4", expected: false},
+		"Package Match":    {spec: "go.eth-p.dev/clout=5", expected: true},
+		"Package Base":     {spec: "clout=5", expected: true},
+		"Directory Match":  {spec: "*/vmodule_test=5", expected: true},
+		"No Match":         {spec: "cache=5", expected: false},
+		"First Match Wins": {spec: "vmodule_test=1,clout=5", expected: false},
+	}
+
+	oldVerbosity := GetVerbosity()
+	defer SetVerbosity(oldVerbosity)
+	defer SetVModule("")
+
+	SetVerbosity(2)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := SetVModule(tc.spec); err != nil {
+				t.Fatal(err)
+			}
+
+			// Check twice to make sure the cached result is the same.
+			for i := 0; i < 2; i++ {
+				if got := V(5).Enabled(); got != tc.expected {
+					t.Fatalf("expected V(5).Enabled() to be %v, got %v", tc.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFunctionPackage(t *testing.T) {
+	tests := map[string]string{
+		"main.main":                         "main",
+		"net/http.(*Server).Serve":          "net/http",
+		"go.eth-p.dev/clout.V":              "go.eth-p.dev/clout",
+		"go.eth-p.dev/clout/pkg/fitm.Parse": "go.eth-p.dev/clout/pkg/fitm",
+	}
+
+	for function, expected := range tests {
+		if got := functionPackage(function); got != expected {
+			t.Fatalf("expected functionPackage(%q) to be %q, got %q", function, expected, got)
+		}
+	}
+}
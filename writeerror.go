@@ -0,0 +1,108 @@
+package clout
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// WriteErrorPolicy decides what a printer does when it fails to write a Message.
+type WriteErrorPolicy int
+
+const (
+	// ExitOnBrokenPipe silently exits the program with code 0 if the output is a closed pipe (EPIPE), and ignores
+	// any other errors. This matches the behavior of coreutils when piped into a command like "head".
+	//
+	// The program exits through Exit once the message has finished printing, so the exit hooks are run and the
+	// printers are flushed first. Messages printed with a Verbose, a Group, or Verbose.AsWriter exit before returning
+	// to the caller. Messages printed by calling PrinterInterface.Print directly exit on the next one of those.
+	//
+	// Outputs created with OutputFromFile for os.Stdout or os.Stderr handle SIGPIPE with signal.Notify, since the Go
+	// runtime would otherwise kill the program with SIGPIPE before the error is seen.
+	//
+	// This is the default policy.
+	ExitOnBrokenPipe WriteErrorPolicy = iota
+
+	// IgnoreWriteErrors ignores all errors.
+	IgnoreWriteErrors WriteErrorPolicy = iota
+
+	// FallbackToStderr writes the message to stderr instead.
+	// If writing to stderr also fails, the error is ignored.
+	FallbackToStderr WriteErrorPolicy = iota
+
+	// PanicOnWriteError panics with the error.
+	PanicOnWriteError WriteErrorPolicy = iota
+
+	// CallWriteErrorHandler calls a function set with SetWriteErrorHandler.
+	CallWriteErrorHandler WriteErrorPolicy = iota
+)
+
+// WriteErrorHandler is a function that is called when a printer fails to write a Message.
+type WriteErrorHandler func(err error, message Message)
+
+// writeErrors handles write errors for a printer according to a WriteErrorPolicy.
+type writeErrors struct {
+	mutex   sync.Mutex
+	policy  WriteErrorPolicy
+	handler WriteErrorHandler
+	stderr  io.Writer
+	err     error
+}
+
+// setPolicy changes the WriteErrorPolicy.
+func (w *writeErrors) setPolicy(policy WriteErrorPolicy) {
+	w.mutex.Lock()
+	w.policy = policy
+	w.mutex.Unlock()
+}
+
+// setHandler changes the WriteErrorHandler, and sets the policy to CallWriteErrorHandler.
+func (w *writeErrors) setHandler(handler WriteErrorHandler) {
+	w.mutex.Lock()
+	w.policy = CallWriteErrorHandler
+	w.handler = handler
+	w.mutex.Unlock()
+}
+
+// lastError returns the last write error.
+func (w *writeErrors) lastError() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.err
+}
+
+// handle handles a write error.
+// The fallback function is called with stderr to write the message there if the policy is FallbackToStderr.
+func (w *writeErrors) handle(err error, message Message, fallback func(stderr io.Writer) error) {
+	w.mutex.Lock()
+	w.err = err
+	policy := w.policy
+	handler := w.handler
+	stderr := w.stderr
+	w.mutex.Unlock()
+
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	switch policy {
+	case ExitOnBrokenPipe:
+		if errors.Is(err, syscall.EPIPE) {
+			setBrokenPipe()
+		}
+
+	case FallbackToStderr:
+		_ = fallback(stderr)
+
+	case PanicOnWriteError:
+		panic(fmt.Errorf("failed to print message; err= %w", err))
+
+	case CallWriteErrorHandler:
+		if handler != nil {
+			handler(err, message)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package clout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.eth-p.dev/clout/pkg/color"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestPrinterWriteErrors(t *testing.T) {
+	errBroken := fmt.Errorf("write /dev/stdout: %w", syscall.EPIPE)
+	errOther := errors.New("disk full")
+
+	tests := map[string]struct {
+		err              error
+		init             func(p *Printer, handled *[]error)
+		expectedExitCode int
+		expectedStderr   string
+		expectedHandled  int
+		expectedPanic    bool
+	}{
+		"Broken Pipe": {
+			err:              errBroken,
+			expectedExitCode: 0,
+		},
+		"Other Error": {
+			err:              errOther,
+			expectedExitCode: -1,
+		},
+		"Ignore Broken Pipe": {
+			err:              errBroken,
+			expectedExitCode: -1,
+			init: func(p *Printer, handled *[]error) {
+				p.SetWriteErrorPolicy(IgnoreWriteErrors)
+			},
+		},
+		"Fallback To Stderr": {
+			err:              errOther,
+			expectedExitCode: -1,
+			expectedStderr:   "error: hello\n",
+			init: func(p *Printer, handled *[]error) {
+				p.SetWriteErrorPolicy(FallbackToStderr)
+			},
+		},
+		"Panic": {
+			err:              errOther,
+			expectedExitCode: -1,
+			expectedPanic:    true,
+			init: func(p *Printer, handled *[]error) {
+				p.SetWriteErrorPolicy(PanicOnWriteError)
+			},
+		},
+		"Handler": {
+			err:              errBroken,
+			expectedExitCode: -1,
+			expectedHandled:  1,
+			init: func(p *Printer, handled *[]error) {
+				p.SetWriteErrorHandler(func(err error, message Message) {
+					*handled = append(*handled, err)
+				})
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			code := captureExit(t)
+			stderr := new(bytes.Buffer)
+			var handled []error
+
+			p := NewPrinter().SetOutput(OutputFromWriter(failingWriter{err: tc.err}).WithPrefix("error:", color.Plain()))
+			p.writeErrors.stderr = stderr
+			if tc.init != nil {
+				tc.init(p, &handled)
+			}
+
+			func() {
+				defer func() {
+					if panicked := recover() != nil; panicked != tc.expectedPanic {
+						t.Fatalf("expected panic to be %t, got %t", tc.expectedPanic, panicked)
+					}
+				}()
+
+				Logger{}.WithPrinter(p).V(0).Errorf("hello")
+			}()
+
+			if *code != tc.expectedExitCode {
+				t.Fatalf("expected exit code %d, got %d", tc.expectedExitCode, *code)
+			}
+
+			if stderr.String() != tc.expectedStderr {
+				t.Fatalf("expected stderr %q, got %q", tc.expectedStderr, stderr.String())
+			}
+
+			if len(handled) != tc.expectedHandled {
+				t.Fatalf("expected handler to be called %d times, got %d", tc.expectedHandled, len(handled))
+			}
+
+			if !errors.Is(p.Err(), tc.err) {
+				t.Fatalf("expected Err to return %v, got %v", tc.err, p.Err())
+			}
+		})
+	}
+}
+
+func TestJSONPrinterWriteErrors(t *testing.T) {
+	code := captureExit(t)
+	stderr := new(bytes.Buffer)
+
+	p := NewJSONPrinter(failingWriter{err: syscall.EPIPE})
+	p.writeErrors.stderr = stderr
+	Logger{}.WithPrinter(p).V(2).Infof("hello")
+
+	if *code != 0 {
+		t.Fatalf("expected exit code 0 on broken pipe, got %d", *code)
+	}
+
+	p.SetWriteErrorPolicy(FallbackToStderr).Print(New(Info, 2, "hello"))
+	if expected := `{"kind":"info","verbosity":2,"message":"hello"}` + "\n"; stderr.String() != expected {
+		t.Fatalf("expected stderr %q, got %q", expected, stderr.String())
+	}
+
+	if !errors.Is(p.Err(), syscall.EPIPE) {
+		t.Fatalf("expected Err to return EPIPE, got %v", p.Err())
+	}
+}
+
+type testOrderPrinter struct {
+	order *[]string
+}
+
+func (p testOrderPrinter) Print(message Message) {
+	*p.order = append(*p.order, "print "+message.String())
+}
+
+func (p testOrderPrinter) Flush(ctx context.Context) error {
+	*p.order = append(*p.order, "flush")
+	return nil
+}
+
+func TestBrokenPipeExit(t *testing.T) {
+	resetGlobals(t)
+	captureExit(t)
+
+	var order []string
+	SetExitFunc(func(code int) { order = append(order, fmt.Sprintf("exit %d", code)) })
+	RegisterExitHook(func() { order = append(order, "hook") })
+
+	// The DedupPrinter is still printing the message when the write fails, so exiting must wait until it is done.
+	broken := NewPrinter().SetOutput(OutputFromWriter(failingWriter{err: syscall.EPIPE}))
+	SetPrinter(NewDedupPrinter(Tee(broken, testOrderPrinter{order: &order})))
+
+	V(0).Infof("first")
+	order = append(order, "returned")
+
+	expected := []string{"print first", "hook", "flush", "exit 0", "returned"}
+	if diff := cmp.Diff(expected, order); diff != "" {
+		t.Log("did not find expected order; want -> -, got -> +")
+		t.Fatalf(diff)
+	}
+}
+
+func TestBrokenPipeExitAsync(t *testing.T) {
+	resetGlobals(t)
+	captureExit(t)
+
+	exited := make(chan int, 1)
+	SetExitFunc(func(code int) { exited <- code })
+
+	hooked := false
+	RegisterExitHook(func() { hooked = true })
+
+	// The AsyncPrinter and DedupPrinter must be flushed without waiting for the message that failed to print.
+	broken := NewPrinter().SetOutput(OutputFromWriter(failingWriter{err: syscall.EPIPE}))
+	queued := &testPrinter{}
+	async := NewAsyncPrinter(Tee(NewDedupPrinter(broken), queued), 16)
+	defer async.Close()
+	SetPrinter(async)
+
+	V(0).Infof("first")
+	V(0).Infof("second")
+
+	select {
+	case code := <-exited:
+		if code != 0 || !hooked {
+			t.Fatalf("expected exit hooks to run and exit code 0, got hooked=%t code=%d", hooked, code)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected broken pipe to exit")
+	}
+
+	if len(queued.messages) != 2 {
+		t.Fatalf("expected queued messages to be printed before exiting, got %d", len(queued.messages))
+	}
+}
+
+// TestBrokenPipeSIGPIPE runs the test binary with its stdout connected to a closed pipe, and checks that it exits
+// through Exit without being killed by SIGPIPE or returning to the code that printed the message.
+func TestBrokenPipeSIGPIPE(t *testing.T) {
+	if os.Getenv("CLOUT_TEST_BROKEN_PIPE") == "1" {
+		RegisterExitHook(func() { fmt.Fprintln(os.Stderr, "exit hook") })
+		SetPrinter(NewPrinter())
+		for i := 0; i < 100; i++ {
+			V(0).Infof("line %d", i)
+		}
+
+		fmt.Fprintln(os.Stderr, "returned after broken pipe")
+		os.Exit(3)
+	}
+
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGPIPE is not used on windows")
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = reader.Close()
+
+	stderr := new(bytes.Buffer)
+	cmd := exec.Command(os.Args[0], "-test.run=^TestBrokenPipeSIGPIPE$")
+	cmd.Env = append(os.Environ(), "CLOUT_TEST_BROKEN_PIPE=1")
+	cmd.Stdout = writer
+	cmd.Stderr = stderr
+	err = cmd.Run()
+	_ = writer.Close()
+
+	if err != nil {
+		t.Fatalf("expected exit code 0, got %v; stderr: %s", err, stderr.String())
+	}
+
+	if stderr.String() != "exit hook\n" {
+		t.Fatalf("expected only the exit hook to print, got %q", stderr.String())
+	}
+}